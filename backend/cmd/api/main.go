@@ -11,8 +11,10 @@ import (
 	"pickem/backend/internal/config"
 	"pickem/backend/internal/database"
 	httpapi "pickem/backend/internal/http"
+	"pickem/backend/internal/notify"
 	"pickem/backend/internal/scheduler"
 	"pickem/backend/internal/store"
+	"pickem/backend/internal/webhooks"
 )
 
 func main() {
@@ -42,7 +44,17 @@ func main() {
 	currentWeekJob.Start(ctx)
 	defer currentWeekJob.Stop()
 
-	srv := httpapi.New(cfg, st)
+	reminderJob := scheduler.NewReminderJob(cfg, st, notify.FromConfig(cfg))
+	reminderJob.Start(ctx)
+	defer reminderJob.Stop()
+
+	dispatcher := webhooks.NewDispatcher(st)
+
+	autoPickJob := scheduler.NewAutoPickJob(cfg, st, dispatcher)
+	autoPickJob.Start(ctx)
+	defer autoPickJob.Stop()
+
+	srv := httpapi.New(cfg, st, dispatcher)
 
 	addr := ":" + cfg.Port
 	log.Printf("Big Dawg Pool API listening on %s", addr)
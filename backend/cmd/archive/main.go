@@ -0,0 +1,113 @@
+// Command archive exports the pool to a portable JSON archive or restores
+// one, straight against the database:
+//
+//	go run ./cmd/archive export [-season <season id>] [-o pickem.json]
+//	go run ./cmd/archive restore [-i pickem.json]
+//
+// It reads SUPABASE_DB_URL from the environment (or .env) and needs the
+// migrations applied to the target database first.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/joho/godotenv"
+
+	"pickem/backend/internal/archive"
+	"pickem/backend/internal/database"
+	"pickem/backend/internal/store"
+)
+
+func main() {
+	_ = godotenv.Load()
+	log.SetFlags(0)
+
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	dbURL := os.Getenv("SUPABASE_DB_URL")
+	if dbURL == "" {
+		log.Fatal("archive: SUPABASE_DB_URL is required")
+	}
+
+	ctx := context.Background()
+	pool, err := database.Connect(ctx, dbURL)
+	if err != nil {
+		log.Fatalf("archive: database: %v", err)
+	}
+	defer pool.Close()
+	st := store.New(pool)
+
+	switch os.Args[1] {
+	case "export":
+		flags := flag.NewFlagSet("export", flag.ExitOnError)
+		seasonID := flags.String("season", "", "only export this season ID")
+		output := flags.String("o", "", "write to this file instead of stdout")
+		_ = flags.Parse(os.Args[2:])
+
+		out, err := st.ExportArchive(ctx, *seasonID)
+		if err != nil {
+			log.Fatalf("archive: export: %v", err)
+		}
+
+		var w io.Writer = os.Stdout
+		if *output != "" {
+			file, err := os.Create(*output)
+			if err != nil {
+				log.Fatalf("archive: %v", err)
+			}
+			defer file.Close()
+			w = file
+		}
+		if err := archive.Encode(w, out); err != nil {
+			log.Fatalf("archive: write: %v", err)
+		}
+		if *output != "" {
+			log.Printf("archive: wrote %d members and %d seasons to %s", len(out.Members), len(out.Seasons), *output)
+		}
+
+	case "restore":
+		flags := flag.NewFlagSet("restore", flag.ExitOnError)
+		input := flags.String("i", "", "read from this file instead of stdin")
+		_ = flags.Parse(os.Args[2:])
+
+		var r io.Reader = os.Stdin
+		if *input != "" {
+			file, err := os.Open(*input)
+			if err != nil {
+				log.Fatalf("archive: %v", err)
+			}
+			defer file.Close()
+			r = file
+		}
+
+		in, err := archive.Decode(r)
+		if err != nil {
+			log.Fatalf("archive: %v", err)
+		}
+		report, err := st.RestoreArchive(ctx, in)
+		if err != nil && report == nil {
+			log.Fatalf("archive: restore: %v", err)
+		}
+		if err != nil {
+			log.Printf("archive: restored, but rebuilding tags and ratings failed: %v", err)
+		}
+		log.Printf("archive: restored %d seasons (%d new), %d members (%d new), %d weeks, %d games, %d picks, %d tie-breakers, %d week results, %d titles",
+			report.Seasons, report.SeasonsCreated, report.Members, report.MembersCreated,
+			report.Weeks, report.Games, report.Picks, report.TieBreakers, report.WeekResults, report.Titles)
+
+	default:
+		usage()
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: archive export [-season <id>] [-o file] | archive restore [-i file]")
+	os.Exit(2)
+}
@@ -0,0 +1,61 @@
+// Command openapi writes the API's OpenAPI document and checks it against
+// the router:
+//
+//	go run ./cmd/openapi [-o openapi.json] [-ts ../src/lib/api/generated.ts]
+//
+// With -ts it also writes the TypeScript types and URL builders the
+// frontend client is built on.
+//
+// It exits non-zero when a route is missing from the document or the
+// document describes a route that no longer exists, so it can run in CI.
+// No database is needed.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"io"
+	"log"
+	"os"
+
+	"pickem/backend/internal/config"
+	httpapi "pickem/backend/internal/http"
+)
+
+func main() {
+	log.SetFlags(0)
+	output := flag.String("o", "", "write to this file instead of stdout")
+	typescript := flag.String("ts", "", "also write TypeScript bindings to this file")
+	flag.Parse()
+
+	server := httpapi.New(config.Config{}, nil, nil)
+	undocumented, stale, err := server.UndocumentedRoutes()
+	if err != nil {
+		log.Fatalf("openapi: walk routes: %v", err)
+	}
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			log.Fatalf("openapi: %v", err)
+		}
+		defer file.Close()
+		w = file
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(server.Spec()); err != nil {
+		log.Fatalf("openapi: write: %v", err)
+	}
+
+	if *typescript != "" {
+		if err := os.WriteFile(*typescript, []byte(server.Spec().TypeScript()), 0o644); err != nil {
+			log.Fatalf("openapi: %v", err)
+		}
+	}
+
+	if len(undocumented) > 0 || len(stale) > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,218 @@
+package archive
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Version is written into every archive. Bump it when the layout changes in
+// a way older readers cannot follow, and teach Decode to upgrade old ones.
+const Version = 1
+
+var ErrUnsupportedVersion = errors.New("archive: unsupported version")
+
+// ErrInvalid is returned for archives that refer to things they do not
+// contain.
+var ErrInvalid = errors.New("archive: invalid archive")
+
+// Archive is a portable copy of the pool. IDs are the source database's
+// UUIDs and only serve to link records within the archive; a restore maps
+// them onto whatever IDs the target database has or assigns. Derived data
+// (game tags, ratings, pick history) is rebuilt rather than archived.
+type Archive struct {
+	Version    int       `json:"version"`
+	ExportedAt time.Time `json:"exportedAt"`
+	Members    []Member  `json:"members"`
+	Seasons    []Season  `json:"seasons"`
+}
+
+type Member struct {
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	IsCommissioner bool   `json:"isCommissioner"`
+}
+
+type Season struct {
+	ID        string    `json:"id"`
+	Label     string    `json:"label"`
+	Year      int       `json:"year"`
+	SeasonKey string    `json:"seasonKey"`
+	Settings  *Settings `json:"settings,omitempty"`
+	Weeks     []Week    `json:"weeks"`
+	Title     *Result   `json:"title,omitempty"`
+}
+
+type Settings struct {
+	CurrentWeek   int    `json:"currentWeek"`
+	TieScoring    string `json:"tieScoring"`
+	StandingsRule string `json:"standingsRule"`
+	AutoPickRule  string `json:"autoPickRule"`
+}
+
+type Week struct {
+	ID          string       `json:"id"`
+	Number      int          `json:"number"`
+	Label       string       `json:"label"`
+	StartsAt    *time.Time   `json:"startsAt,omitempty"`
+	EndsAt      *time.Time   `json:"endsAt,omitempty"`
+	Games       []Game       `json:"games"`
+	TieBreakers []TieBreaker `json:"tieBreakers"`
+	Result      *Result      `json:"result,omitempty"`
+}
+
+type Game struct {
+	ID            string     `json:"id"`
+	GameKey       string     `json:"gameKey"`
+	Kickoff       *time.Time `json:"kickoff,omitempty"`
+	Status        string     `json:"status"`
+	Channel       string     `json:"channel,omitempty"`
+	Location      string     `json:"location,omitempty"`
+	HomeTeam      string     `json:"homeTeam"`
+	AwayTeam      string     `json:"awayTeam"`
+	HomeScore     *int       `json:"homeScore,omitempty"`
+	AwayScore     *int       `json:"awayScore,omitempty"`
+	Winner        string     `json:"winner,omitempty"`
+	ResultSource  string     `json:"resultSource"`
+	ResultReason  string     `json:"resultReason,omitempty"`
+	MovedFromWeek *int       `json:"movedFromWeek,omitempty"`
+	PointSpread   *float64   `json:"pointSpread,omitempty"`
+	OverUnder     *float64   `json:"overUnder,omitempty"`
+	HomeMoneyline *int       `json:"homeMoneyline,omitempty"`
+	AwayMoneyline *int       `json:"awayMoneyline,omitempty"`
+	Picks         []Pick     `json:"picks"`
+}
+
+type Pick struct {
+	MemberID  string `json:"memberId"`
+	Side      string `json:"side"`
+	Auto      bool   `json:"auto,omitempty"`
+	EnteredBy string `json:"enteredBy,omitempty"`
+}
+
+type TieBreaker struct {
+	MemberID string `json:"memberId"`
+	Points   int    `json:"points"`
+}
+
+// Result is a declared week winner or season title.
+type Result struct {
+	WinnerID     string    `json:"winnerId,omitempty"`
+	Notes        string    `json:"notes,omitempty"`
+	DeclaredByID string    `json:"declaredById,omitempty"`
+	DeclaredAt   time.Time `json:"declaredAt"`
+}
+
+// Encode writes the archive as indented JSON.
+func Encode(w io.Writer, a *Archive) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(a)
+}
+
+// Decode reads and validates an archive.
+func Decode(r io.Reader) (*Archive, error) {
+	var a Archive
+	if err := json.NewDecoder(r).Decode(&a); err != nil {
+		return nil, fmt.Errorf("archive: decode: %w", err)
+	}
+	if a.Version != Version {
+		return nil, fmt.Errorf("%w %d (this build reads version %d)", ErrUnsupportedVersion, a.Version, Version)
+	}
+	if err := a.Validate(); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+// Validate checks that every member reference resolves within the archive
+// and that seasons, weeks and games are not repeated.
+func (a *Archive) Validate() error {
+	members := map[string]bool{}
+	// Import matches members by name ignoring case, so "Sam" and "sam" would
+	// land on the same person.
+	names := map[string]bool{}
+	for _, member := range a.Members {
+		if member.ID == "" || member.Name == "" {
+			return fmt.Errorf("%w: members need an id and a name", ErrInvalid)
+		}
+		name := strings.ToLower(member.Name)
+		if members[member.ID] || names[name] {
+			return fmt.Errorf("%w: member %q appears twice", ErrInvalid, member.Name)
+		}
+		members[member.ID] = true
+		names[name] = true
+	}
+	known := func(id, what string) error {
+		if id != "" && !members[id] {
+			return fmt.Errorf("%w: %s refers to unknown member %s", ErrInvalid, what, id)
+		}
+		return nil
+	}
+
+	seasons := map[string]bool{}
+	gameKeys := map[string]bool{}
+	for _, season := range a.Seasons {
+		if season.SeasonKey == "" {
+			return fmt.Errorf("%w: season %q has no season key", ErrInvalid, season.Label)
+		}
+		if seasons[season.SeasonKey] {
+			return fmt.Errorf("%w: season %s appears twice", ErrInvalid, season.SeasonKey)
+		}
+		seasons[season.SeasonKey] = true
+		if season.Title != nil {
+			if err := known(season.Title.WinnerID, season.SeasonKey+" title"); err != nil {
+				return err
+			}
+			if err := known(season.Title.DeclaredByID, season.SeasonKey+" title"); err != nil {
+				return err
+			}
+		}
+
+		weeks := map[int]bool{}
+		for _, week := range season.Weeks {
+			if weeks[week.Number] {
+				return fmt.Errorf("%w: %s week %d appears twice", ErrInvalid, season.SeasonKey, week.Number)
+			}
+			weeks[week.Number] = true
+			where := fmt.Sprintf("%s week %d", season.SeasonKey, week.Number)
+			if week.Result != nil {
+				if err := known(week.Result.WinnerID, where+" result"); err != nil {
+					return err
+				}
+				if err := known(week.Result.DeclaredByID, where+" result"); err != nil {
+					return err
+				}
+			}
+			for _, tb := range week.TieBreakers {
+				if err := known(tb.MemberID, where+" tie-breaker"); err != nil {
+					return err
+				}
+			}
+			for _, game := range week.Games {
+				if game.GameKey == "" || gameKeys[game.GameKey] {
+					return fmt.Errorf("%w: %s has a missing or repeated game key %q", ErrInvalid, where, game.GameKey)
+				}
+				gameKeys[game.GameKey] = true
+				for _, pick := range game.Picks {
+					if pick.MemberID == "" {
+						return fmt.Errorf("%w: game %s has a pick without a member", ErrInvalid, game.GameKey)
+					}
+					if pick.Side != "home" && pick.Side != "away" {
+						return fmt.Errorf("%w: game %s has a pick for side %q", ErrInvalid, game.GameKey, pick.Side)
+					}
+					if err := known(pick.MemberID, "game "+game.GameKey+" pick"); err != nil {
+						return err
+					}
+					if err := known(pick.EnteredBy, "game "+game.GameKey+" pick"); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
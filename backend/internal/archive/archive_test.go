@@ -0,0 +1,31 @@
+package archive
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateMembers(t *testing.T) {
+	tests := []struct {
+		name    string
+		members []Member
+		wantErr bool
+	}{
+		{"distinct", []Member{{ID: "1", Name: "Sam"}, {ID: "2", Name: "Alex"}}, false},
+		{"repeated id", []Member{{ID: "1", Name: "Sam"}, {ID: "1", Name: "Alex"}}, true},
+		{"repeated name", []Member{{ID: "1", Name: "Sam"}, {ID: "2", Name: "Sam"}}, true},
+		{"names differing only in case", []Member{{ID: "1", Name: "Sam"}, {ID: "2", Name: "sam"}}, true},
+		{"missing name", []Member{{ID: "1"}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&Archive{Version: Version, Members: tt.members}).Validate()
+			if tt.wantErr && !errors.Is(err, ErrInvalid) {
+				t.Fatalf("Validate() = %v, want ErrInvalid", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("Validate() = %v", err)
+			}
+		})
+	}
+}
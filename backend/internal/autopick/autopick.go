@@ -0,0 +1,79 @@
+package autopick
+
+import (
+	"pickem/backend/internal/models"
+	"pickem/backend/internal/odds"
+)
+
+// Auto-pick rules for season_settings.auto_pick_rule.
+const (
+	RuleNone       = "none"
+	RuleHome       = "home"
+	RuleFavorite   = "favorite"
+	RuleConsensus  = "consensus"
+	RuleFadeLeader = "fade-leader"
+)
+
+// ValidRule reports whether rule is one of the supported auto-pick rules.
+func ValidRule(rule string) bool {
+	switch rule {
+	case RuleNone, RuleHome, RuleFavorite, RuleConsensus, RuleFadeLeader:
+		return true
+	}
+	return false
+}
+
+// Choose picks a side for memberID on a locked game. Each rule falls back
+// to the next simpler one when it has nothing to go on: fading the leader
+// falls back to the consensus, the consensus (when the family splits or
+// nobody picked) to the favorite, and a game without lines to the home
+// team. Earlier auto picks are ignored so they never feed the consensus.
+// It returns "" for RuleNone.
+func Choose(rule string, game models.Game, memberID, leaderID string) string {
+	switch rule {
+	case RuleFadeLeader:
+		if leaderID != "" && leaderID != memberID {
+			for _, pick := range game.Picks {
+				if pick.MemberID == leaderID && !pick.Auto {
+					return opposite(pick.ChosenSide)
+				}
+			}
+		}
+		fallthrough
+	case RuleConsensus:
+		home, away := 0, 0
+		for _, pick := range game.Picks {
+			if pick.Auto {
+				continue
+			}
+			switch pick.ChosenSide {
+			case "home":
+				home++
+			case "away":
+				away++
+			}
+		}
+		if home > away {
+			return "home"
+		}
+		if away > home {
+			return "away"
+		}
+		fallthrough
+	case RuleFavorite:
+		if favorite := odds.Favorite(game); favorite != "" {
+			return favorite
+		}
+		fallthrough
+	case RuleHome:
+		return "home"
+	}
+	return ""
+}
+
+func opposite(side string) string {
+	if side == "home" {
+		return "away"
+	}
+	return "home"
+}
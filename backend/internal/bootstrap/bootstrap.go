@@ -72,7 +72,7 @@ func ensureSeason(ctx context.Context, pool *pgxpool.Pool, seasonKey string) (st
 		return "", fmt.Errorf("bootstrap: SPORTS_SEASON_KEY must be provided")
 	}
 
-	year, label, err := seasonLabel(seasonKey)
+	year, label, err := SeasonLabel(seasonKey)
 	if err != nil {
 		return "", err
 	}
@@ -130,7 +130,9 @@ func ensureSeasonSettings(ctx context.Context, pool *pgxpool.Pool, seasonID stri
 	return nil
 }
 
-func seasonLabel(seasonKey string) (int, string, error) {
+// SeasonLabel derives the year and display label from a SportsData season
+// key such as "2024REG" or "2023POST".
+func SeasonLabel(seasonKey string) (int, string, error) {
 	yearDigits := 0
 	for _, r := range seasonKey {
 		if !unicode.IsDigit(r) {
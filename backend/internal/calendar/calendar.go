@@ -0,0 +1,247 @@
+package calendar
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"pickem/backend/internal/models"
+)
+
+const (
+	// gameLength is how long a game event blocks out; the feed has no
+	// real end times.
+	gameLength = 3*time.Hour + 15*time.Minute
+	// deadlineLength is the width of the pick deadline event, ending at the
+	// week's first kickoff.
+	deadlineLength = 30 * time.Minute
+	// deadlineAlarm is how long before the deadline calendar clients remind.
+	deadlineAlarm = 2 * time.Hour
+	// uidDomain suffixes every UID so they are unique across calendars.
+	uidDomain = "pickem"
+)
+
+// Week is one week of games for the feed.
+type Week struct {
+	Number int
+	Label  string
+	Games  []models.Game
+}
+
+// Input is everything the feed draws on. MemberID, when set, personalises
+// the feed with that member's picks and how many games they have left.
+type Input struct {
+	Season     models.Season
+	Weeks      []Week
+	MemberID   string
+	MemberName string
+	// PicksOpen reports whether a game still takes picks, by the same rule
+	// the API enforces. Nil treats every scheduled game as open.
+	PicksOpen func(models.Game) bool
+}
+
+// Event is one VEVENT.
+type Event struct {
+	UID         string
+	Start       time.Time
+	End         time.Time
+	Summary     string
+	Description string
+	Location    string
+	Canceled    bool
+	// Alarm, when non-zero, adds a display reminder this long before Start.
+	Alarm time.Duration
+}
+
+// Calendar is one VCALENDAR.
+type Calendar struct {
+	Name   string
+	Events []Event
+}
+
+// Build lists every game with a kickoff plus a pick deadline per week at
+// the week's first kickoff, when its first games lock; each game locks at
+// its own kickoff. Game UIDs come from the game key and deadline
+// UIDs from the season key and week number, so clients update events in
+// place when kickoffs move.
+func Build(input Input) Calendar {
+	picksOpen := input.PicksOpen
+	if picksOpen == nil {
+		picksOpen = func(game models.Game) bool { return game.Status == models.GameStatusScheduled }
+	}
+
+	cal := Calendar{Name: input.Season.Label + " picks"}
+	if input.MemberName != "" {
+		cal.Name = fmt.Sprintf("%s picks (%s)", input.Season.Label, input.MemberName)
+	}
+
+	for _, week := range input.Weeks {
+		var deadline *time.Time
+		open, missing := 0, 0
+		for _, game := range week.Games {
+			if game.Kickoff == nil {
+				continue
+			}
+			canceled := game.Status == models.GameStatusCanceled
+			if !canceled && (deadline == nil || game.Kickoff.Before(*deadline)) {
+				kickoff := *game.Kickoff
+				deadline = &kickoff
+			}
+
+			pick := memberPick(game, input.MemberID)
+			if !canceled && picksOpen(game) {
+				open++
+				if pick == "" {
+					missing++
+				}
+			}
+
+			cal.Events = append(cal.Events, Event{
+				UID:         fmt.Sprintf("%s@%s", game.GameKey, uidDomain),
+				Start:       *game.Kickoff,
+				End:         game.Kickoff.Add(gameLength),
+				Summary:     gameSummary(game),
+				Description: gameDescription(week, game, pick),
+				Location:    game.Location,
+				Canceled:    canceled,
+			})
+		}
+		if deadline == nil {
+			continue
+		}
+
+		description := fmt.Sprintf("The first %s games lock at kickoff. Every other game takes picks until its own kickoff.", week.Label)
+		if input.MemberID != "" && open > 0 {
+			description += fmt.Sprintf(" %d of %d open games still need a pick.", missing, open)
+		}
+		cal.Events = append(cal.Events, Event{
+			UID:         fmt.Sprintf("deadline-%s-week-%d@%s", input.Season.SportsDataSeasonKey, week.Number, uidDomain),
+			Start:       deadline.Add(-deadlineLength),
+			End:         *deadline,
+			Summary:     fmt.Sprintf("%s picks start locking", week.Label),
+			Description: description,
+			Alarm:       deadlineAlarm,
+		})
+	}
+
+	return cal
+}
+
+func memberPick(game models.Game, memberID string) string {
+	if memberID == "" {
+		return ""
+	}
+	for _, pick := range game.Picks {
+		if pick.MemberID == memberID {
+			return pick.ChosenSide
+		}
+	}
+	return ""
+}
+
+func gameSummary(game models.Game) string {
+	summary := fmt.Sprintf("%s @ %s", teamName(game.AwayTeam), teamName(game.HomeTeam))
+	if game.HomeScore != nil && game.AwayScore != nil &&
+		(game.Status == models.GameStatusFinal || game.Status == models.GameStatusForfeit) {
+		summary = fmt.Sprintf("%s %d @ %s %d (final)", teamName(game.AwayTeam), *game.AwayScore, teamName(game.HomeTeam), *game.HomeScore)
+	}
+	if game.Status == models.GameStatusCanceled {
+		summary += " (canceled)"
+	}
+	return summary
+}
+
+func gameDescription(week Week, game models.Game, pick string) string {
+	lines := []string{week.Label}
+	if game.Channel != "" {
+		lines = append(lines, "TV: "+game.Channel)
+	}
+	switch pick {
+	case "home":
+		lines = append(lines, "Your pick: "+teamName(game.HomeTeam))
+	case "away":
+		lines = append(lines, "Your pick: "+teamName(game.AwayTeam))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func teamName(team models.TeamInfo) string {
+	if team.Location == "" || team.Location == team.Name {
+		return team.Name
+	}
+	return team.Location + " " + team.Name
+}
+
+// Write renders the calendar as RFC 5545 text with CRLF line endings and
+// long lines folded. stamp is used for every DTSTAMP.
+func Write(w io.Writer, cal Calendar, stamp time.Time) error {
+	out := &writer{w: w}
+	out.line("BEGIN:VCALENDAR")
+	out.line("VERSION:2.0")
+	out.line("PRODID:-//Big Dog Pool//Pickem//EN")
+	out.line("CALSCALE:GREGORIAN")
+	out.line("METHOD:PUBLISH")
+	out.line("X-WR-CALNAME:" + escape(cal.Name))
+	for _, event := range cal.Events {
+		out.line("BEGIN:VEVENT")
+		out.line("UID:" + event.UID)
+		out.line("DTSTAMP:" + formatTime(stamp))
+		out.line("DTSTART:" + formatTime(event.Start))
+		out.line("DTEND:" + formatTime(event.End))
+		out.line("SUMMARY:" + escape(event.Summary))
+		if event.Description != "" {
+			out.line("DESCRIPTION:" + escape(event.Description))
+		}
+		if event.Location != "" {
+			out.line("LOCATION:" + escape(event.Location))
+		}
+		if event.Canceled {
+			out.line("STATUS:CANCELLED")
+		}
+		if event.Alarm > 0 {
+			out.line("BEGIN:VALARM")
+			out.line("ACTION:DISPLAY")
+			out.line("DESCRIPTION:" + escape(event.Summary))
+			out.line(fmt.Sprintf("TRIGGER:-PT%dM", int(event.Alarm.Minutes())))
+			out.line("END:VALARM")
+		}
+		out.line("END:VEVENT")
+	}
+	out.line("END:VCALENDAR")
+	return out.err
+}
+
+type writer struct {
+	w   io.Writer
+	err error
+}
+
+// line writes one content line, folding it at 75 octets without splitting
+// a UTF-8 sequence.
+func (w *writer) line(text string) {
+	if w.err != nil {
+		return
+	}
+	var b strings.Builder
+	width := 0
+	for _, r := range text {
+		size := len(string(r))
+		if width+size > 75 {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += size
+	}
+	b.WriteString("\r\n")
+	_, w.err = io.WriteString(w.w, b.String())
+}
+
+func escape(text string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(text)
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
@@ -0,0 +1,47 @@
+package calendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"pickem/backend/internal/models"
+)
+
+func TestBuildDeadlineCountsOpenGames(t *testing.T) {
+	now := time.Date(2024, 9, 8, 18, 0, 0, 0, time.UTC)
+	early := now.Add(-time.Hour)
+	late := now.Add(3 * time.Hour)
+	later := now.Add(6 * time.Hour)
+
+	week := Week{Number: 1, Label: "Week 1", Games: []models.Game{
+		// Kicked off but not yet synced to in progress: locked.
+		{GameKey: "g1", Kickoff: &early, Status: models.GameStatusScheduled},
+		{GameKey: "g2", Kickoff: &late, Status: models.GameStatusScheduled,
+			Picks: []models.GamePick{{MemberID: "m1", ChosenSide: "home"}}},
+		{GameKey: "g3", Kickoff: &later, Status: models.GameStatusScheduled},
+	}}
+	cal := Build(Input{
+		Weeks:    []Week{week},
+		MemberID: "m1",
+		PicksOpen: func(game models.Game) bool {
+			return game.Status == models.GameStatusScheduled && game.Kickoff.After(now)
+		},
+	})
+
+	var deadline *Event
+	for i := range cal.Events {
+		if strings.HasPrefix(cal.Events[i].UID, "deadline-") {
+			deadline = &cal.Events[i]
+		}
+	}
+	if deadline == nil {
+		t.Fatal("no deadline event")
+	}
+	if !deadline.End.Equal(early) {
+		t.Errorf("deadline ends %v, want the first kickoff %v", deadline.End, early)
+	}
+	if !strings.Contains(deadline.Description, "1 of 2 open games still need a pick") {
+		t.Errorf("description = %q", deadline.Description)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // Config holds runtime configuration for the Go API service.
@@ -18,6 +19,19 @@ type Config struct {
 	EnableSportsSync bool
 	FamilyMembers    []string
 	CommissionerName string
+
+	// Missing-pick reminders. Each channel is enabled once its settings are
+	// present.
+	ReminderLead     time.Duration
+	SMTPHost         string
+	SMTPPort         string
+	SMTPUsername     string
+	SMTPPassword     string
+	SMTPFrom         string
+	MemberEmails     map[string]string
+	NotifyWebhookURL string
+	NtfyURL          string
+	NtfyTopic        string
 }
 
 // Load reads configuration from environment variables.
@@ -41,6 +55,16 @@ func Load() (Config, error) {
 		DefaultSeasonKey: os.Getenv("SPORTS_SEASON_KEY"),
 		FamilyMembers:    familyMembers,
 		CommissionerName: commissioner,
+		ReminderLead:     24 * time.Hour,
+		SMTPHost:         os.Getenv("SMTP_HOST"),
+		SMTPPort:         getEnvOrDefault("SMTP_PORT", "587"),
+		SMTPUsername:     os.Getenv("SMTP_USERNAME"),
+		SMTPPassword:     os.Getenv("SMTP_PASSWORD"),
+		SMTPFrom:         os.Getenv("SMTP_FROM"),
+		MemberEmails:     map[string]string{},
+		NotifyWebhookURL: os.Getenv("NOTIFY_WEBHOOK_URL"),
+		NtfyURL:          getEnvOrDefault("NTFY_URL", "https://ntfy.sh"),
+		NtfyTopic:        os.Getenv("NTFY_TOPIC"),
 	}
 
 	if cfg.DatabaseURL == "" {
@@ -65,6 +89,24 @@ func Load() (Config, error) {
 		cfg.EnableSportsSync = cfg.SportsAPIKey != ""
 	}
 
+	if rawLead := os.Getenv("REMINDER_LEAD"); rawLead != "" {
+		lead, err := time.ParseDuration(rawLead)
+		if err != nil || lead <= 0 {
+			return Config{}, fmt.Errorf("config: invalid REMINDER_LEAD value %q", rawLead)
+		}
+		cfg.ReminderLead = lead
+	}
+
+	// MEMBER_EMAILS pairs roster names with addresses: "Brad=brad@example.com,Mom=mom@example.com".
+	for _, pair := range splitAndTrim(os.Getenv("MEMBER_EMAILS"), ",") {
+		name, email, ok := strings.Cut(pair, "=")
+		name, email = strings.TrimSpace(name), strings.TrimSpace(email)
+		if !ok || name == "" || email == "" {
+			return Config{}, fmt.Errorf("config: invalid MEMBER_EMAILS entry %q", pair)
+		}
+		cfg.MemberEmails[name] = email
+	}
+
 	return cfg, nil
 }
 
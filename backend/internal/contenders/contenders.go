@@ -0,0 +1,242 @@
+package contenders
+
+import (
+	"sort"
+
+	"pickem/backend/internal/models"
+	"pickem/backend/internal/standings"
+)
+
+// Pick is one member's side on a game that has not been decided yet.
+type Pick struct {
+	MemberID string
+	Side     string
+}
+
+// RemainingGame is an undecided game and the picks made on it.
+type RemainingGame struct {
+	GameKey string
+	Picks   []Pick
+}
+
+// Week reports which members can still finish the week with the most
+// points. With allowTies a share of first place counts as winning, which is
+// how the family settles it before tie breakers. tiePoints is what a correct
+// pick on a tied game is worth (0, 0.5 or 1 under the season's tie scoring);
+// any remaining game may still end tied.
+//
+// Rather than walking every 3^N outcome, each member is checked on its own:
+// the member's own picks are always best resolved in their favour, so only
+// the games they skipped need searching, and that search stops as soon as
+// every rival is safely capped.
+func Week(memberIDs []string, current map[string]float64, remaining []RemainingGame, allowTies bool, tiePoints float64) []models.WeekContender {
+	results := make([]models.WeekContender, 0, len(memberIDs))
+	for _, memberID := range memberIDs {
+		maxPoints := current[memberID]
+		for _, game := range remaining {
+			if sideFor(game, memberID) != "" {
+				maxPoints++
+			}
+		}
+		results = append(results, models.WeekContender{
+			MemberID:      memberID,
+			CurrentPoints: current[memberID],
+			MaxPoints:     maxPoints,
+			CanWin:        canWinWeek(memberID, memberIDs, current, remaining, allowTies, tiePoints),
+		})
+	}
+	return results
+}
+
+func canWinWeek(memberID string, memberIDs []string, current map[string]float64, remaining []RemainingGame, allowTies bool, tiePoints float64) bool {
+	scores := map[string]float64{}
+	for _, id := range memberIDs {
+		scores[id] = current[id]
+	}
+
+	// Resolve the member's own picks their way. For any rival this is never
+	// worse than the alternatives: a rival on the same side gains what the
+	// member gains, a rival on the other side gains nothing, and a tie would
+	// give the member no more than a win.
+	open := []RemainingGame{}
+	for _, game := range remaining {
+		side := sideFor(game, memberID)
+		if side == "" {
+			open = append(open, game)
+			continue
+		}
+		for _, pick := range game.Picks {
+			if pick.Side == side {
+				scores[pick.MemberID]++
+			}
+		}
+	}
+
+	// capacity is how many more points each rival may collect and still
+	// finish level with the member. Without ties a rival must stay strictly
+	// below, so a capacity of zero already loses.
+	within := func(gain, limit float64) bool {
+		if allowTies {
+			return gain <= limit
+		}
+		return gain < limit
+	}
+	capacity := map[string]float64{}
+	for _, id := range memberIDs {
+		if id == memberID {
+			continue
+		}
+		capacity[id] = scores[memberID] - scores[id]
+		if !within(0, capacity[id]) {
+			return false
+		}
+	}
+
+	// Search the most contested games first so conflicts surface early.
+	sort.SliceStable(open, func(i, j int) bool {
+		return len(open[i].Picks) > len(open[j].Picks)
+	})
+
+	// exposure[i][id] is the most the rival can still gain from open[i:]:
+	// a point for each game they picked. Once every rival's capacity covers
+	// it, any outcome works.
+	exposure := make([]map[string]float64, len(open)+1)
+	exposure[len(open)] = map[string]float64{}
+	for i := len(open) - 1; i >= 0; i-- {
+		exposure[i] = map[string]float64{}
+		for id, count := range exposure[i+1] {
+			exposure[i][id] = count
+		}
+		for _, pick := range open[i].Picks {
+			if pick.MemberID != memberID {
+				exposure[i][pick.MemberID]++
+			}
+		}
+	}
+
+	// gain is what a pick earns under each outcome of an open game.
+	gain := func(pick Pick, outcome string) float64 {
+		switch {
+		case outcome == models.GameWinnerTie:
+			return tiePoints
+		case pick.Side == outcome:
+			return 1
+		}
+		return 0
+	}
+
+	var search func(index int) bool
+	search = func(index int) bool {
+		safe := true
+		for id, limit := range capacity {
+			if !within(0, limit) {
+				return false
+			}
+			if !within(exposure[index][id], limit) {
+				safe = false
+			}
+		}
+		if safe {
+			return true
+		}
+		for _, outcome := range []string{"home", "away", models.GameWinnerTie} {
+			for _, pick := range open[index].Picks {
+				if pick.MemberID != memberID {
+					capacity[pick.MemberID] -= gain(pick, outcome)
+				}
+			}
+			ok := search(index + 1)
+			for _, pick := range open[index].Picks {
+				if pick.MemberID != memberID {
+					capacity[pick.MemberID] += gain(pick, outcome)
+				}
+			}
+			if ok {
+				return true
+			}
+		}
+		return false
+	}
+
+	return search(0)
+}
+
+func sideFor(game RemainingGame, memberID string) string {
+	for _, pick := range game.Picks {
+		if pick.MemberID == memberID {
+			return pick.Side
+		}
+	}
+	return ""
+}
+
+// SeasonMember is a member's season position for title math.
+type SeasonMember struct {
+	MemberID string
+	WeeksWon int
+	Record   models.RecordSummary
+}
+
+// Season reports who can still take the title under the standings rule.
+// Weeks won is exact: a member can win every remaining week while rivals win
+// none. The pick-based rules use the same best case against each rival's
+// worst case, which treats future picks as independent.
+func Season(rule string, members []SeasonMember, remainingWeeks, remainingGames int, allowTies bool) []models.SeasonContender {
+	results := make([]models.SeasonContender, 0, len(members))
+	for _, member := range members {
+		best := bestCase(rule, member, remainingWeeks, remainingGames)
+		canWin := true
+		for _, rival := range members {
+			if rival.MemberID == member.MemberID {
+				continue
+			}
+			worst := worstCase(rule, rival, remainingGames)
+			if best < worst || (!allowTies && best == worst) {
+				canWin = false
+				break
+			}
+		}
+		results = append(results, models.SeasonContender{
+			MemberID:    member.MemberID,
+			Current:     currentMetric(rule, member),
+			BestCase:    best,
+			CanWinTitle: canWin,
+		})
+	}
+	return results
+}
+
+func currentMetric(rule string, member SeasonMember) float64 {
+	switch rule {
+	case standings.RuleTotalCorrect:
+		return member.Record.Points
+	case standings.RuleWinPct:
+		return standings.WinPct(member.Record)
+	}
+	return float64(member.WeeksWon)
+}
+
+func bestCase(rule string, member SeasonMember, remainingWeeks, remainingGames int) float64 {
+	switch rule {
+	case standings.RuleTotalCorrect:
+		return member.Record.Points + float64(remainingGames)
+	case standings.RuleWinPct:
+		record := member.Record
+		record.Wins += remainingGames
+		record.Points += float64(remainingGames)
+		return standings.WinPct(record)
+	}
+	return float64(member.WeeksWon + remainingWeeks)
+}
+
+func worstCase(rule string, member SeasonMember, remainingGames int) float64 {
+	switch rule {
+	case standings.RuleTotalCorrect:
+		return member.Record.Points
+	case standings.RuleWinPct:
+		record := member.Record
+		record.Losses += remainingGames
+		return standings.WinPct(record)
+	}
+	return float64(member.WeeksWon)
+}
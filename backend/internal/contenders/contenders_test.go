@@ -0,0 +1,159 @@
+package contenders
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"pickem/backend/internal/models"
+)
+
+func TestCanWinWeek(t *testing.T) {
+	members := []string{"a", "b", "c"}
+	tests := []struct {
+		name      string
+		current   map[string]float64
+		remaining []RemainingGame
+		allowTies bool
+		tiePoints float64
+		want      map[string]bool
+	}{
+		{
+			name:      "half point lead with nothing left",
+			current:   map[string]float64{"a": 3.5, "b": 3, "c": 1},
+			allowTies: false,
+			tiePoints: 0.5,
+			want:      map[string]bool{"a": true, "b": false, "c": false},
+		},
+		{
+			name:      "level on half points shares the week",
+			current:   map[string]float64{"a": 2.5, "b": 2.5, "c": 2},
+			allowTies: true,
+			tiePoints: 0.5,
+			want:      map[string]bool{"a": true, "b": true, "c": false},
+		},
+		{
+			name:      "level on half points without ties",
+			current:   map[string]float64{"a": 2.5, "b": 2.5, "c": 2},
+			allowTies: false,
+			tiePoints: 0.5,
+			want:      map[string]bool{"a": false, "b": false, "c": false},
+		},
+		{
+			name:    "only a tied game keeps a alive",
+			current: map[string]float64{"a": 2, "b": 1.5, "c": 1.5},
+			remaining: []RemainingGame{{GameKey: "g1", Picks: []Pick{
+				{MemberID: "b", Side: "home"},
+				{MemberID: "c", Side: "away"},
+			}}},
+			allowTies: true,
+			tiePoints: 0.5,
+			want:      map[string]bool{"a": true, "b": true, "c": true},
+		},
+		{
+			name:    "a tie worth a full point does not help",
+			current: map[string]float64{"a": 2, "b": 1.5, "c": 1.5},
+			remaining: []RemainingGame{{GameKey: "g1", Picks: []Pick{
+				{MemberID: "b", Side: "home"},
+				{MemberID: "c", Side: "away"},
+			}}},
+			allowTies: true,
+			tiePoints: 1,
+			want:      map[string]bool{"a": false, "b": true, "c": true},
+		},
+		{
+			name:    "own pick draws level",
+			current: map[string]float64{"a": 1, "b": 2, "c": 0},
+			remaining: []RemainingGame{{GameKey: "g1", Picks: []Pick{
+				{MemberID: "a", Side: "home"},
+				{MemberID: "b", Side: "away"},
+			}}},
+			allowTies: true,
+			tiePoints: 0,
+			want:      map[string]bool{"a": true, "b": true, "c": false},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, contender := range Week(members, tt.current, tt.remaining, tt.allowTies, tt.tiePoints) {
+				if contender.CanWin != tt.want[contender.MemberID] {
+					t.Errorf("%s: CanWin = %v, want %v", contender.MemberID, contender.CanWin, tt.want[contender.MemberID])
+				}
+			}
+		})
+	}
+}
+
+// TestCanWinWeekMatchesExhaustiveSearch checks the pruned search against
+// every home, away and tie outcome of small random slates.
+func TestCanWinWeekMatchesExhaustiveSearch(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	members := []string{"a", "b", "c", "d"}
+	for trial := 0; trial < 400; trial++ {
+		current := map[string]float64{}
+		for _, id := range members {
+			current[id] = float64(rng.Intn(7)) / 2
+		}
+		var remaining []RemainingGame
+		for g := 0; g < 1+rng.Intn(5); g++ {
+			game := RemainingGame{GameKey: fmt.Sprintf("g%d", g)}
+			for _, id := range members {
+				switch rng.Intn(3) {
+				case 0:
+					game.Picks = append(game.Picks, Pick{MemberID: id, Side: "home"})
+				case 1:
+					game.Picks = append(game.Picks, Pick{MemberID: id, Side: "away"})
+				}
+			}
+			remaining = append(remaining, game)
+		}
+		allowTies := rng.Intn(2) == 0
+		tiePoints := []float64{0, 0.5, 1}[rng.Intn(3)]
+
+		for _, id := range members {
+			got := canWinWeek(id, members, current, remaining, allowTies, tiePoints)
+			want := exhaustiveCanWin(id, members, current, remaining, allowTies, tiePoints)
+			if got != want {
+				t.Fatalf("trial %d: canWinWeek(%s) = %v, exhaustive search says %v\ncurrent %v allowTies %v tiePoints %v\nremaining %+v",
+					trial, id, got, want, current, allowTies, tiePoints, remaining)
+			}
+		}
+	}
+}
+
+func exhaustiveCanWin(memberID string, members []string, current map[string]float64, remaining []RemainingGame, allowTies bool, tiePoints float64) bool {
+	outcomes := []string{"home", "away", models.GameWinnerTie}
+	var walk func(index int, scores map[string]float64) bool
+	walk = func(index int, scores map[string]float64) bool {
+		if index == len(remaining) {
+			for _, id := range members {
+				if id == memberID {
+					continue
+				}
+				if scores[id] > scores[memberID] || (!allowTies && scores[id] == scores[memberID]) {
+					return false
+				}
+			}
+			return true
+		}
+		for _, outcome := range outcomes {
+			next := map[string]float64{}
+			for id, score := range scores {
+				next[id] = score
+			}
+			for _, pick := range remaining[index].Picks {
+				switch {
+				case outcome == models.GameWinnerTie:
+					next[pick.MemberID] += tiePoints
+				case pick.Side == outcome:
+					next[pick.MemberID]++
+				}
+			}
+			if walk(index+1, next) {
+				return true
+			}
+		}
+		return false
+	}
+	return walk(0, current)
+}
@@ -0,0 +1,274 @@
+package feed
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"pickem/backend/internal/models"
+	"pickem/backend/internal/standings"
+)
+
+const (
+	atomNamespace = "http://www.w3.org/2005/Atom"
+	// authorName credits every entry; feed readers require an author and
+	// declarations come from whichever commissioner was on duty.
+	authorName = "Big Dog Pool"
+)
+
+// Result is one declared week winner or season title.
+type Result struct {
+	ID         string
+	WinnerID   string
+	Notes      string
+	DeclaredAt time.Time
+}
+
+// Week is one season week and its declaration, if any.
+type Week struct {
+	Number int
+	Label  string
+	Result *Result
+}
+
+// Input is everything the feed draws on. Members carry each member's graded
+// record per week, as loaded for standings.
+type Input struct {
+	Season  models.Season
+	Members []standings.MemberWeeks
+	Weeks   []Week
+	Title   *Result
+	SelfURL string
+}
+
+// Feed is an Atom feed document.
+type Feed struct {
+	XMLName xml.Name `xml:"feed"`
+	XMLNS   string   `xml:"xmlns,attr"`
+	ID      string   `xml:"id"`
+	Title   string   `xml:"title"`
+	Updated string   `xml:"updated"`
+	Author  Person   `xml:"author"`
+	Links   []Link   `xml:"link"`
+	Entries []Entry  `xml:"entry"`
+}
+
+type Person struct {
+	Name string `xml:"name"`
+}
+
+type Link struct {
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+	Href string `xml:"href,attr"`
+}
+
+type Text struct {
+	Type string `xml:"type,attr"`
+	Body string `xml:",chardata"`
+}
+
+type Entry struct {
+	ID       string   `xml:"id"`
+	Title    string   `xml:"title"`
+	Updated  string   `xml:"updated"`
+	Category Category `xml:"category"`
+	Content  Text     `xml:"content"`
+}
+
+type Category struct {
+	Term string `xml:"term,attr"`
+}
+
+// Build lists the season title, when declared, and every declared week,
+// newest declaration first. Entry IDs come from the declaration rows, so
+// re-declaring a week updates its entry rather than adding one.
+func Build(input Input) Feed {
+	out := Feed{
+		XMLNS:  atomNamespace,
+		ID:     "urn:uuid:" + input.Season.ID,
+		Title:  input.Season.Label + " results",
+		Author: Person{Name: authorName},
+	}
+	if input.SelfURL != "" {
+		out.Links = append(out.Links, Link{Rel: "self", Type: "application/atom+xml", Href: input.SelfURL})
+	}
+
+	names := map[string]string{}
+	for _, member := range input.Members {
+		names[member.MemberID] = member.Name
+	}
+
+	type dated struct {
+		at    time.Time
+		entry Entry
+	}
+	var entries []dated
+	for _, week := range input.Weeks {
+		if week.Result == nil {
+			continue
+		}
+		entries = append(entries, dated{week.Result.DeclaredAt, weekEntry(week, names, input.Members)})
+	}
+	if input.Title != nil {
+		entries = append(entries, dated{input.Title.DeclaredAt, titleEntry(input, names)})
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].at.After(entries[j].at) })
+
+	// An empty feed is still valid Atom; the season's own start stands in
+	// for the missing declaration time.
+	updated := time.Date(input.Season.Year, time.September, 1, 0, 0, 0, 0, time.UTC)
+	if len(entries) > 0 {
+		updated = entries[0].at
+	}
+	out.Updated = formatTime(updated)
+	for _, e := range entries {
+		out.Entries = append(out.Entries, e.entry)
+	}
+	return out
+}
+
+func weekEntry(week Week, names map[string]string, members []standings.MemberWeeks) Entry {
+	result := week.Result
+	winner := names[result.WinnerID]
+	title := fmt.Sprintf("%s: no winner", week.Label)
+	if winner != "" {
+		title = fmt.Sprintf("%s: %s wins", week.Label, winner)
+	}
+
+	var lines []string
+	if winner != "" {
+		line := fmt.Sprintf("%s won %s", winner, week.Label)
+		for _, member := range members {
+			if member.MemberID == result.WinnerID {
+				if record, ok := member.Records[week.Number]; ok {
+					line += " going " + formatRecord(record)
+				}
+			}
+		}
+		lines = append(lines, line+".")
+	} else {
+		lines = append(lines, fmt.Sprintf("No winner was declared for %s.", week.Label))
+	}
+	if notes := strings.TrimSpace(result.Notes); notes != "" {
+		lines = append(lines, notes)
+	}
+
+	var field []string
+	for _, member := range rankedForWeek(members, week.Number) {
+		field = append(field, fmt.Sprintf("%s %s", member.Name, formatRecord(member.Records[week.Number])))
+	}
+	if len(field) > 0 {
+		lines = append(lines, "Records: "+strings.Join(field, ", ")+".")
+	}
+
+	return Entry{
+		ID:       "urn:uuid:" + result.ID,
+		Title:    title,
+		Updated:  formatTime(result.DeclaredAt),
+		Category: Category{Term: "week-result"},
+		Content:  Text{Type: "text", Body: strings.Join(lines, "\n\n")},
+	}
+}
+
+func titleEntry(input Input, names map[string]string) Entry {
+	result := input.Title
+	winner := names[result.WinnerID]
+	title := fmt.Sprintf("%s: no champion", input.Season.Label)
+	if winner != "" {
+		title = fmt.Sprintf("%s champion: %s", input.Season.Label, winner)
+	}
+
+	var lines []string
+	if winner != "" {
+		line := fmt.Sprintf("%s took the %s title", winner, input.Season.Label)
+		for _, member := range input.Members {
+			if member.MemberID != result.WinnerID {
+				continue
+			}
+			line += " going " + formatRecord(seasonRecord(member))
+			if won := len(member.WeeksWon); won > 0 {
+				line += fmt.Sprintf(" with %d weekly %s", won, plural(won, "win", "wins"))
+			}
+		}
+		lines = append(lines, line+".")
+	} else {
+		lines = append(lines, fmt.Sprintf("The %s season closed without a champion.", input.Season.Label))
+	}
+	if notes := strings.TrimSpace(result.Notes); notes != "" {
+		lines = append(lines, notes)
+	}
+
+	return Entry{
+		ID:       "urn:uuid:" + result.ID,
+		Title:    title,
+		Updated:  formatTime(result.DeclaredAt),
+		Category: Category{Term: "season-title"},
+		Content:  Text{Type: "text", Body: strings.Join(lines, "\n\n")},
+	}
+}
+
+// rankedForWeek returns the members with a record for the week, best
+// record first and by name within a tie.
+func rankedForWeek(members []standings.MemberWeeks, week int) []standings.MemberWeeks {
+	var out []standings.MemberWeeks
+	for _, member := range members {
+		if _, ok := member.Records[week]; ok {
+			out = append(out, member)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		a, b := out[i].Records[week], out[j].Records[week]
+		if a.Points != b.Points {
+			return a.Points > b.Points
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
+
+func seasonRecord(member standings.MemberWeeks) models.RecordSummary {
+	var total models.RecordSummary
+	for _, record := range member.Records {
+		total.Wins += record.Wins
+		total.Losses += record.Losses
+		total.Ties += record.Ties
+		total.Points += record.Points
+	}
+	return total
+}
+
+func formatRecord(record models.RecordSummary) string {
+	if record.Ties > 0 {
+		return fmt.Sprintf("%d-%d-%d", record.Wins, record.Losses, record.Ties)
+	}
+	return fmt.Sprintf("%d-%d", record.Wins, record.Losses)
+}
+
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// Write renders the feed as an XML document.
+func Write(w io.Writer, f Feed) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(f); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,205 @@
+package gametags
+
+import (
+	"math"
+
+	"pickem/backend/internal/models"
+	"pickem/backend/internal/odds"
+)
+
+// Tag marks one game as the week's holder of a tag. Value carries the
+// measure behind it: the upset's improbability or the closest margin.
+type Tag struct {
+	GameID string
+	Tag    string
+	Value  *float64
+}
+
+// Week is a week's games and how it was settled.
+type Week struct {
+	Games []models.Game
+	// WinnerID is the declared week winner, if any.
+	WinnerID string
+	// TieBreakerGameID is the game whose total settles level weeks.
+	TieBreakerGameID string
+	TiePoints        float64
+}
+
+// Compute derives the week's tags from its graded games. Tags that no game
+// qualifies for are left out.
+func Compute(week Week) []Tag {
+	tags := []Tag{}
+	if tag, ok := biggestUpset(week.Games); ok {
+		tags = append(tags, tag)
+	}
+	if tag, ok := closestGame(week.Games); ok {
+		tags = append(tags, tag)
+	}
+	if tag, ok := decidedWeek(week); ok {
+		tags = append(tags, tag)
+	}
+	return tags
+}
+
+// biggestUpset scores each game by how unlikely the winner was: by the
+// betting line when there is one, otherwise by the share of the family that
+// picked the loser.
+func biggestUpset(games []models.Game) (Tag, bool) {
+	var best Tag
+	bestScore := 0.5
+	for _, game := range games {
+		if !graded(game) || (game.Winner != "home" && game.Winner != "away") {
+			continue
+		}
+
+		score := 0.0
+		if home, ok := odds.HomeWinProbability(game); ok {
+			score = 1 - home
+			if game.Winner == "away" {
+				score = home
+			}
+		} else {
+			wrong, total := 0, 0
+			for _, pick := range game.Picks {
+				total++
+				if pick.ChosenSide != game.Winner {
+					wrong++
+				}
+			}
+			if total < 2 {
+				continue
+			}
+			score = float64(wrong) / float64(total)
+		}
+
+		if score > bestScore {
+			value := score
+			best = Tag{GameID: game.ID, Tag: models.GameTagBiggestUpset, Value: &value}
+			bestScore = score
+		}
+	}
+	return best, best.GameID != ""
+}
+
+func closestGame(games []models.Game) (Tag, bool) {
+	var best Tag
+	bestMargin := math.Inf(1)
+	for _, game := range games {
+		if !graded(game) || game.HomeScore == nil || game.AwayScore == nil {
+			continue
+		}
+		margin := math.Abs(float64(*game.HomeScore - *game.AwayScore))
+		if margin < bestMargin {
+			value := margin
+			best = Tag{GameID: game.ID, Tag: models.GameTagClosestGame, Value: &value}
+			bestMargin = margin
+		}
+	}
+	return best, best.GameID != ""
+}
+
+// decidedWeek finds the game that separated the week's winner from the
+// runner-up: the last game to kick off that the winner got right and the
+// runner-up did not. When the two finished level the tie-breaker game
+// decided it. Without a declared winner the sole points leader of a fully
+// graded week stands in.
+func decidedWeek(week Week) (Tag, bool) {
+	points := map[string]float64{}
+	correct := map[string]map[string]bool{}
+	settled := true
+	for _, game := range week.Games {
+		correct[game.ID] = map[string]bool{}
+		if !graded(game) && game.Status != models.GameStatusCanceled {
+			settled = false
+		}
+		for _, pick := range game.Picks {
+			if _, ok := points[pick.MemberID]; !ok {
+				points[pick.MemberID] = 0
+			}
+			switch pick.Status {
+			case models.PickStatusCorrect:
+				points[pick.MemberID]++
+				correct[game.ID][pick.MemberID] = true
+			case models.PickStatusPush:
+				points[pick.MemberID] += week.TiePoints
+			}
+		}
+	}
+
+	winnerID := week.WinnerID
+	if winnerID == "" {
+		if !settled {
+			return Tag{}, false
+		}
+		leaders := leadersOf(points, "")
+		if len(leaders) != 1 {
+			return Tag{}, false
+		}
+		winnerID = leaders[0]
+	}
+
+	runnersUp := leadersOf(points, winnerID)
+	if len(runnersUp) == 0 {
+		return Tag{}, false
+	}
+	if points[runnersUp[0]] >= points[winnerID] {
+		if week.TieBreakerGameID == "" {
+			return Tag{}, false
+		}
+		return Tag{GameID: week.TieBreakerGameID, Tag: models.GameTagDecidedWeek}, true
+	}
+
+	var decider *models.Game
+	for i := range week.Games {
+		game := &week.Games[i]
+		if !correct[game.ID][winnerID] {
+			continue
+		}
+		swing := true
+		for _, runnerUp := range runnersUp {
+			if correct[game.ID][runnerUp] {
+				swing = false
+			}
+		}
+		if !swing {
+			continue
+		}
+		if decider == nil || later(game, decider) {
+			decider = game
+		}
+	}
+	if decider == nil {
+		return Tag{}, false
+	}
+	return Tag{GameID: decider.ID, Tag: models.GameTagDecidedWeek}, true
+}
+
+// leadersOf returns the members with the most points, leaving out exclude.
+func leadersOf(points map[string]float64, exclude string) []string {
+	best := math.Inf(-1)
+	leaders := []string{}
+	for memberID, value := range points {
+		if memberID == exclude {
+			continue
+		}
+		switch {
+		case value > best:
+			best = value
+			leaders = append(leaders[:0], memberID)
+		case value == best:
+			leaders = append(leaders, memberID)
+		}
+	}
+	return leaders
+}
+
+func later(a, b *models.Game) bool {
+	if a.Kickoff == nil || b.Kickoff == nil {
+		return a.Kickoff != nil
+	}
+	return a.Kickoff.After(*b.Kickoff)
+}
+
+func graded(game models.Game) bool {
+	return game.Status == models.GameStatusFinal || game.Status == models.GameStatusForfeit
+}
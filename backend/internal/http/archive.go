@@ -0,0 +1,80 @@
+package httpapi
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"pickem/backend/internal/archive"
+	"pickem/backend/internal/store"
+)
+
+// maxArchiveBytes caps uploaded archives; years of family picks come to a
+// few megabytes at most.
+const maxArchiveBytes = 64 << 20
+
+// handleExportArchive downloads the pool (or one season with ?seasonId=) as
+// a JSON archive. Commissioner only.
+func (s *Server) handleExportArchive(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query := r.URL.Query()
+	if !s.requireCommissioner(ctx, w, query.Get("memberId")) {
+		return
+	}
+
+	out, err := s.store.ExportArchive(ctx, strings.TrimSpace(query.Get("seasonId")))
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, store.ErrSeasonNotFound) {
+			status = http.StatusNotFound
+		}
+		writeError(w, status, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="pickem-%s.json"`, out.ExportedAt.Format("20060102-150405")))
+	if err := archive.Encode(w, out); err != nil {
+		log.Printf("http: export archive: %v", err)
+	}
+}
+
+// handleRestoreArchive loads a JSON archive posted as the request body.
+// Commissioner only; see store.RestoreArchive for how records are matched.
+func (s *Server) handleRestoreArchive(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if !s.requireCommissioner(ctx, w, r.URL.Query().Get("memberId")) {
+		return
+	}
+
+	in, err := archive.Decode(http.MaxBytesReader(w, r.Body, maxArchiveBytes))
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, archive.ErrInvalid) || errors.Is(err, archive.ErrUnsupportedVersion) {
+			status = http.StatusUnprocessableEntity
+		}
+		writeError(w, status, err)
+		return
+	}
+
+	started := time.Now()
+	report, err := s.store.RestoreArchive(ctx, in)
+	if err != nil && report == nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, store.ErrImportConflict) {
+			status = http.StatusConflict
+		}
+		writeError(w, status, err)
+		return
+	}
+	if err != nil {
+		// The data is in; only the rebuilt tags or ratings failed.
+		log.Printf("http: rebuild after restore: %v", err)
+	}
+	log.Printf("http: restored %d seasons and %d picks in %s", report.Seasons, report.Picks, time.Since(started).Round(time.Millisecond))
+
+	writeJSON(w, http.StatusOK, map[string]any{"restore": report})
+}
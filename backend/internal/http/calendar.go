@@ -0,0 +1,41 @@
+package httpapi
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"pickem/backend/internal/calendar"
+	"pickem/backend/internal/store"
+)
+
+// handleGetCalendar serves the season as an iCalendar feed for calendar
+// apps to subscribe to. ?memberId= personalises it with that member's picks.
+func (s *Server) handleGetCalendar(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	seasonID := chi.URLParam(r, "seasonID")
+	if strings.TrimSpace(seasonID) == "" {
+		writeError(w, http.StatusBadRequest, errors.New("seasonID is required"))
+		return
+	}
+
+	cal, err := s.store.GetSeasonCalendar(ctx, seasonID, r.URL.Query().Get("memberId"))
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, store.ErrSeasonNotFound) || errors.Is(err, store.ErrMemberNotFound) {
+			status = http.StatusNotFound
+		}
+		writeError(w, status, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="pickem.ics"`)
+	if err := calendar.Write(w, *cal, time.Now()); err != nil {
+		log.Printf("http: write calendar for season %s: %v", seasonID, err)
+	}
+}
@@ -0,0 +1,41 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"pickem/backend/internal/store"
+)
+
+func (s *Server) handleGetContenders(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	seasonID, weekNumber, err := parseSeasonWeekParams(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	allowTies := true
+	if raw := strings.TrimSpace(r.URL.Query().Get("allowTies")); raw != "" {
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, errors.New("allowTies must be a boolean"))
+			return
+		}
+		allowTies = value
+	}
+
+	report, err := s.store.GetContenders(ctx, seasonID, weekNumber, allowTies)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, store.ErrSeasonNotFound) || errors.Is(err, store.ErrWeekNotFound) {
+			status = http.StatusNotFound
+		}
+		writeError(w, status, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"contenders": report})
+}
@@ -0,0 +1,287 @@
+package httpapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"pickem/backend/internal/bootstrap"
+	"pickem/backend/internal/config"
+	"pickem/backend/internal/store"
+	"pickem/backend/internal/webhooks"
+)
+
+// TestContractWithDatabase calls every documented operation against a
+// seeded database and validates each response against the document. It
+// runs in a throwaway schema and only when PICKEM_TEST_DATABASE_URL is set.
+func TestContractWithDatabase(t *testing.T) {
+	databaseURL := os.Getenv("PICKEM_TEST_DATABASE_URL")
+	if databaseURL == "" {
+		t.Skip("PICKEM_TEST_DATABASE_URL is not set")
+	}
+	ctx := context.Background()
+	pool := contractPool(ctx, t, databaseURL)
+
+	cfg := config.Config{
+		FamilyMembers:    []string{"Brad", "Mom", "Dad"},
+		CommissionerName: "Brad",
+		DefaultSeasonKey: "2025REG",
+	}
+	if err := bootstrap.Run(ctx, pool, cfg); err != nil {
+		t.Fatalf("bootstrap: %v", err)
+	}
+	ids := seedContractData(ctx, t, pool)
+
+	st := store.New(pool)
+	s := New(cfg, st, webhooks.NewDispatcher(st))
+	exercised := map[string]bool{}
+	call := func(method, path string, body any) *httptest.ResponseRecorder {
+		t.Helper()
+		var reader io.Reader
+		switch body := body.(type) {
+		case nil:
+		case string:
+			reader = strings.NewReader(body)
+		case []byte:
+			reader = bytes.NewReader(body)
+		default:
+			raw, err := json.Marshal(body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			reader = bytes.NewReader(raw)
+		}
+		req := httptest.NewRequest(method, path, reader)
+		rec := serveAndValidate(t, s, req)
+		if template, ok := s.Spec().Match(method, req.URL.Path); ok {
+			exercised[method+" "+template] = true
+		}
+		return rec
+	}
+	expect := func(rec *httptest.ResponseRecorder, status int) {
+		t.Helper()
+		if rec.Code != status {
+			t.Errorf("status = %d, want %d: %s", rec.Code, status, rec.Body.String())
+		}
+	}
+
+	season := "/api/seasons/" + ids.season
+	week1, week2 := season+"/weeks/1", season+"/weeks/2"
+	commissioner := "?memberId=" + ids.brad
+
+	expect(call(http.MethodGet, "/healthz", nil), http.StatusOK)
+	expect(call(http.MethodGet, "/api/openapi.json", nil), http.StatusOK)
+	expect(call(http.MethodGet, "/api/seasons", nil), http.StatusOK)
+	expect(call(http.MethodGet, season+"/settings", nil), http.StatusOK)
+	expect(call(http.MethodPost, season+"/settings", map[string]string{"tieScoring": "half"}), http.StatusOK)
+	expect(call(http.MethodGet, season+"/weeks", nil), http.StatusOK)
+	expect(call(http.MethodGet, season+"/weeks/current", nil), http.StatusOK)
+
+	// Week 2 is still open: save, parse, clear and list picks.
+	expect(call(http.MethodPost, week2+"/picks", map[string]string{"memberId": ids.mom, "gameKey": "CT2-BUF-NYJ", "side": "home"}), http.StatusOK)
+	expect(call(http.MethodPost, week2+"/picks", map[string]string{"memberId": ids.mom, "gameKey": "CT2-KC-DEN", "side": "away", "enteredBy": ids.brad}), http.StatusOK)
+	expect(call(http.MethodPost, week2+"/picks/parse", map[string]any{"memberId": ids.dad, "message": "Bills, KC, 41"}), http.StatusOK)
+	expect(call(http.MethodPost, week2+"/picks/parse", map[string]any{"memberId": ids.dad, "message": "Bills, KC, 41", "commit": true}), http.StatusOK)
+	expect(call(http.MethodPost, week2+"/tie-breaker", map[string]any{"memberId": ids.mom, "points": 44}), http.StatusOK)
+	expect(call(http.MethodDelete, week2+"/picks", map[string]string{"memberId": ids.mom, "gameKey": "CT2-KC-DEN"}), http.StatusOK)
+	expect(call(http.MethodGet, week2+"/picks/history", nil), http.StatusOK)
+	expect(call(http.MethodGet, week2+"/contenders", nil), http.StatusOK)
+	expect(call(http.MethodGet, week2+"/probabilities?iterations=200&seed=1", nil), http.StatusOK)
+
+	// Week 1 is graded: correct a result, declare the winner, then read it
+	// back through every derived view.
+	expect(call(http.MethodPost, week1+"/games/CT1-MIA-NE/winner", map[string]any{"winner": "away", "reason": "contract test"}), http.StatusOK)
+	expect(call(http.MethodPost, week1+"/games/CT1-MIA-NE/unlock", nil), http.StatusOK)
+	expect(call(http.MethodPost, week1+"/games/CT1-MIA-NE/winner", map[string]any{"winner": "home", "reason": "contract test"}), http.StatusOK)
+	expect(call(http.MethodPost, week1+"/winner", map[string]string{"winnerMemberId": ids.mom, "declaredByMemberId": ids.brad, "notes": "Clean sweep"}), http.StatusOK)
+	expect(call(http.MethodGet, week1, nil), http.StatusOK)
+	expect(call(http.MethodGet, week1+"/recap", nil), http.StatusOK)
+	expect(call(http.MethodGet, week1+"/recap?format=json", nil), http.StatusOK)
+	expect(call(http.MethodGet, season+"/standings", nil), http.StatusOK)
+	expect(call(http.MethodGet, season+"/ratings", nil), http.StatusOK)
+	expect(call(http.MethodGet, season+"/members/"+ids.mom+"/stats", nil), http.StatusOK)
+	expect(call(http.MethodGet, season+"/members/"+ids.mom+"/head-to-head/"+ids.dad, nil), http.StatusOK)
+	expect(call(http.MethodGet, "/api/members/"+ids.mom+"/head-to-head/"+ids.dad, nil), http.StatusOK)
+	expect(call(http.MethodGet, "/api/hall-of-fame", nil), http.StatusOK)
+	expect(call(http.MethodGet, season+"/calendar.ics?memberId="+ids.mom, nil), http.StatusOK)
+	expect(call(http.MethodGet, season+"/feed.atom", nil), http.StatusOK)
+
+	// No sports API key is configured, so syncing answers with an error;
+	// that error still has to match the document.
+	call(http.MethodPost, week1+"/sync", map[string]bool{"dryRun": true})
+
+	export := call(http.MethodGet, season+"/export.csv", nil)
+	expect(export, http.StatusOK)
+	expect(call(http.MethodPost, "/api/seasons/import"+commissioner+"&seasonKey=2025REG&dryRun=true", export.Body.Bytes()), http.StatusOK)
+	archive := call(http.MethodGet, "/api/archive"+commissioner, nil)
+	expect(archive, http.StatusOK)
+	expect(call(http.MethodPost, "/api/archive/restore"+commissioner, archive.Body.Bytes()), http.StatusOK)
+
+	created := call(http.MethodPost, "/api/webhooks", map[string]any{"memberId": ids.brad, "url": "http://127.0.0.1:1/hook", "events": []string{"week.winner"}})
+	expect(created, http.StatusCreated)
+	var webhook struct {
+		Webhook struct {
+			ID string `json:"id"`
+		} `json:"webhook"`
+	}
+	if err := json.Unmarshal(created.Body.Bytes(), &webhook); err != nil || webhook.Webhook.ID == "" {
+		t.Fatalf("decode created webhook: %v: %s", err, created.Body.String())
+	}
+	expect(call(http.MethodGet, "/api/webhooks"+commissioner, nil), http.StatusOK)
+	expect(call(http.MethodGet, "/api/webhooks/"+webhook.Webhook.ID+"/deliveries"+commissioner, nil), http.StatusOK)
+	expect(call(http.MethodDelete, "/api/webhooks/"+webhook.Webhook.ID+commissioner, nil), http.StatusOK)
+
+	// Error responses share the documented envelope.
+	expect(call(http.MethodGet, season+"/weeks/99", nil), http.StatusNotFound)
+	expect(call(http.MethodPost, week1+"/picks", map[string]string{"memberId": ids.mom, "gameKey": "CT1-MIA-NE", "side": "home"}), http.StatusConflict)
+	expect(call(http.MethodGet, "/api/webhooks?memberId="+ids.mom, nil), http.StatusForbidden)
+
+	var missing []string
+	for _, route := range s.Spec().Routes() {
+		if !exercised[route] {
+			missing = append(missing, route)
+		}
+	}
+	sort.Strings(missing)
+	if len(missing) > 0 {
+		t.Errorf("operations not exercised: %v", missing)
+	}
+}
+
+// contractPool connects to a fresh schema with the migrations applied and
+// drops it when the test ends.
+func contractPool(ctx context.Context, t *testing.T, databaseURL string) *pgxpool.Pool {
+	t.Helper()
+	schema := fmt.Sprintf("contract_%d", time.Now().UnixNano())
+
+	admin, err := pgxpool.New(ctx, databaseURL)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	if _, err := admin.Exec(ctx, "create schema "+schema); err != nil {
+		admin.Close()
+		t.Fatalf("create schema: %v", err)
+	}
+
+	parsed, err := url.Parse(databaseURL)
+	if err != nil {
+		t.Fatalf("parse database url: %v", err)
+	}
+	query := parsed.Query()
+	query.Set("search_path", schema+",public")
+	parsed.RawQuery = query.Encode()
+	pool, err := pgxpool.New(ctx, parsed.String())
+	if err != nil {
+		t.Fatalf("connect to schema: %v", err)
+	}
+	t.Cleanup(func() {
+		pool.Close()
+		if _, err := admin.Exec(context.Background(), "drop schema "+schema+" cascade"); err != nil {
+			t.Logf("drop schema %s: %v", schema, err)
+		}
+		admin.Close()
+	})
+
+	files, err := filepath.Glob(filepath.Join("..", "..", "migrations", "*.sql"))
+	if err != nil || len(files) == 0 {
+		t.Fatalf("find migrations: %v", err)
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		sql, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := pool.Exec(ctx, string(sql)); err != nil {
+			t.Fatalf("apply %s: %v", filepath.Base(file), err)
+		}
+	}
+	return pool
+}
+
+type contractIDs struct {
+	season, brad, mom, dad string
+}
+
+// seedContractData adds two weeks of games: week 1 graded with picks from
+// everyone, week 2 scheduled a week out.
+func seedContractData(ctx context.Context, t *testing.T, pool *pgxpool.Pool) contractIDs {
+	t.Helper()
+	var ids contractIDs
+	if err := pool.QueryRow(ctx, `select id::text from seasons where sportsdata_season_key = '2025REG'`).Scan(&ids.season); err != nil {
+		t.Fatalf("seed: season: %v", err)
+	}
+	for name, id := range map[string]*string{"Brad": &ids.brad, "Mom": &ids.mom, "Dad": &ids.dad} {
+		if err := pool.QueryRow(ctx, `select id::text from family_members where name = $1`, name).Scan(id); err != nil {
+			t.Fatalf("seed: member %s: %v", name, err)
+		}
+	}
+
+	team := func(code, name, location string) string {
+		return fmt.Sprintf(`{"code":%q,"name":%q,"location":%q}`, code, name, location)
+	}
+	lastWeek := time.Now().Add(-7 * 24 * time.Hour)
+	nextWeek := time.Now().Add(7 * 24 * time.Hour)
+	games := []struct {
+		week       int
+		key        string
+		kickoff    time.Time
+		status     string
+		home, away string
+		homeScore  any
+		awayScore  any
+		winner     any
+	}{
+		{1, "CT1-MIA-NE", lastWeek, "final", team("NE", "Patriots", "New England"), team("MIA", "Dolphins", "Miami"), 24, 17, "home"},
+		{1, "CT1-DAL-PHI", lastWeek.Add(3 * time.Hour), "final", team("PHI", "Eagles", "Philadelphia"), team("DAL", "Cowboys", "Dallas"), 20, 20, "tie"},
+		{2, "CT2-BUF-NYJ", nextWeek, "scheduled", team("BUF", "Bills", "Buffalo"), team("NYJ", "Jets", "New York"), nil, nil, nil},
+		{2, "CT2-KC-DEN", nextWeek.Add(3 * time.Hour), "scheduled", team("DEN", "Broncos", "Denver"), team("KC", "Chiefs", "Kansas City"), nil, nil, nil},
+	}
+	for _, game := range games {
+		if _, err := pool.Exec(ctx, `
+			insert into games (season_week_id, game_key, kickoff, status, home_team, away_team, home_score, away_score, winner)
+			select id, $3, $4, $5, $6::jsonb, $7::jsonb, $8, $9, $10
+			from season_weeks where season_id = $1 and number = $2
+		`, ids.season, game.week, game.key, game.kickoff, game.status, game.home, game.away, game.homeScore, game.awayScore, game.winner); err != nil {
+			t.Fatalf("seed: game %s: %v", game.key, err)
+		}
+	}
+
+	for member, sides := range map[string][2]string{ids.brad: {"home", "away"}, ids.mom: {"home", "home"}, ids.dad: {"away", "away"}} {
+		for i, key := range []string{"CT1-MIA-NE", "CT1-DAL-PHI"} {
+			if _, err := pool.Exec(ctx, `
+				insert into picks (member_id, game_id, chosen_side)
+				select $1, id, $3 from games where game_key = $2
+			`, member, key, sides[i]); err != nil {
+				t.Fatalf("seed: pick: %v", err)
+			}
+		}
+		if _, err := pool.Exec(ctx, `
+			insert into tie_breakers (member_id, season_week_id, points)
+			select $1, id, 40 from season_weeks where season_id = $2 and number = 1
+		`, member, ids.season); err != nil {
+			t.Fatalf("seed: tie-breaker: %v", err)
+		}
+	}
+
+	if _, err := pool.Exec(ctx, `
+		insert into season_titles (season_id, winner_member_id, notes, declared_by_member_id)
+		values ($1, $2, 'Contract champion', $3)
+	`, ids.season, ids.mom, ids.brad); err != nil {
+		t.Fatalf("seed: season title: %v", err)
+	}
+	return ids
+}
@@ -0,0 +1,77 @@
+package httpapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"pickem/backend/internal/config"
+)
+
+// serveAndValidate sends req through the router and checks the response
+// against the OpenAPI document.
+func serveAndValidate(t *testing.T, s *Server, req *http.Request) *httptest.ResponseRecorder {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, req)
+	if err := s.Spec().ValidateResponse(req.Method, req.URL.Path, rec.Code, rec.Header().Get("Content-Type"), rec.Body.Bytes()); err != nil {
+		t.Errorf("%s %s: %v\nbody: %s", req.Method, req.URL, err, rec.Body.String())
+	}
+	return rec
+}
+
+func TestSpecCoversRouter(t *testing.T) {
+	s := New(config.Config{}, nil, nil)
+	undocumented, stale, err := s.UndocumentedRoutes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(undocumented) > 0 || len(stale) > 0 {
+		t.Fatalf("undocumented routes %v, stale operations %v", undocumented, stale)
+	}
+}
+
+func TestContractWithoutDatabase(t *testing.T) {
+	s := New(config.Config{}, nil, nil)
+
+	for _, path := range []string{"/healthz", "/api/openapi.json"} {
+		rec := serveAndValidate(t, s, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != http.StatusOK {
+			t.Errorf("GET %s = %d, want 200", path, rec.Code)
+		}
+	}
+
+	// Every week route rejects a malformed week number before it reaches
+	// the store, so the error envelope can be checked without a database.
+	for _, route := range s.Spec().Routes() {
+		method, template, _ := strings.Cut(route, " ")
+		if !strings.Contains(template, "{weekNumber}") {
+			continue
+		}
+		path := strings.NewReplacer(
+			"{seasonID}", "season",
+			"{weekNumber}", "first",
+			"{gameKey}", "game",
+		).Replace(template)
+		t.Run(route, func(t *testing.T) {
+			rec := serveAndValidate(t, s, httptest.NewRequest(method, path, strings.NewReader("{}")))
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("status = %d, want 400", rec.Code)
+			}
+		})
+	}
+}
+
+func TestGeneratedTypeScriptIsCurrent(t *testing.T) {
+	path := filepath.Join("..", "..", "..", "src", "lib", "api", "generated.ts")
+	current, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(current) != New(config.Config{}, nil, nil).Spec().TypeScript() {
+		t.Fatalf("%s is out of date; run go run ./cmd/openapi -o /dev/null -ts %s", path, path)
+	}
+}
@@ -0,0 +1,52 @@
+package httpapi
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"pickem/backend/internal/feed"
+	"pickem/backend/internal/store"
+)
+
+// handleGetFeed serves the season's declared results as an Atom feed for
+// feed readers.
+func (s *Server) handleGetFeed(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	seasonID := chi.URLParam(r, "seasonID")
+	if strings.TrimSpace(seasonID) == "" {
+		writeError(w, http.StatusBadRequest, errors.New("seasonID is required"))
+		return
+	}
+
+	out, err := s.store.GetSeasonFeed(ctx, seasonID, requestURL(r))
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, store.ErrSeasonNotFound) {
+			status = http.StatusNotFound
+		}
+		writeError(w, status, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/atom+xml; charset=utf-8")
+	if err := feed.Write(w, *out); err != nil {
+		log.Printf("http: write feed for season %s: %v", seasonID, err)
+	}
+}
+
+// requestURL reconstructs the absolute URL the client asked for, honouring
+// the proxy's forwarded scheme.
+func requestURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if forwarded := r.Header.Get("X-Forwarded-Proto"); forwarded != "" {
+		scheme = strings.TrimSpace(strings.Split(forwarded, ",")[0])
+	}
+	return scheme + "://" + r.Host + r.URL.RequestURI()
+}
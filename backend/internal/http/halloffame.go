@@ -0,0 +1,13 @@
+package httpapi
+
+import "net/http"
+
+func (s *Server) handleGetHallOfFame(w http.ResponseWriter, r *http.Request) {
+	hallOfFame, err := s.store.GetHallOfFame(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"hallOfFame": hallOfFame})
+}
@@ -0,0 +1,39 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"pickem/backend/internal/store"
+)
+
+// handleGetHeadToHead serves both the season route and the all-time route,
+// which has no seasonID.
+func (s *Server) handleGetHeadToHead(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	seasonID := strings.TrimSpace(chi.URLParam(r, "seasonID"))
+	memberID := strings.TrimSpace(chi.URLParam(r, "memberID"))
+	opponentID := strings.TrimSpace(chi.URLParam(r, "opponentID"))
+	if memberID == "" || opponentID == "" {
+		writeError(w, http.StatusBadRequest, errors.New("memberID and opponentID are required"))
+		return
+	}
+
+	result, err := s.store.GetHeadToHead(ctx, seasonID, memberID, opponentID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, store.ErrSeasonNotFound), errors.Is(err, store.ErrMemberNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, store.ErrSameMember):
+			status = http.StatusBadRequest
+		}
+		writeError(w, status, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"headToHead": result})
+}
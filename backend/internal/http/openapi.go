@@ -0,0 +1,355 @@
+package httpapi
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"pickem/backend/internal/archive"
+	"pickem/backend/internal/autopick"
+	"pickem/backend/internal/models"
+	"pickem/backend/internal/openapi"
+	"pickem/backend/internal/recap"
+	"pickem/backend/internal/seasoncsv"
+	"pickem/backend/internal/standings"
+)
+
+// specVersion is the API version in the OpenAPI document. Bump it when a
+// response shape changes in a way the frontend has to follow.
+const specVersion = "1.0.0"
+
+// handleGetOpenAPI serves the OpenAPI document for the routes in routes().
+func (s *Server) handleGetOpenAPI(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.spec)
+}
+
+// Spec returns the OpenAPI document describing every route.
+func (s *Server) Spec() *openapi.Document {
+	return s.spec
+}
+
+// UndocumentedRoutes compares the router against the OpenAPI document and
+// returns the routes the document is missing and the operations it
+// describes that the router no longer serves.
+func (s *Server) UndocumentedRoutes() (undocumented, stale []string, err error) {
+	var served []string
+	err = chi.Walk(s.router, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		served = append(served, openapi.RouteKey(method, strings.TrimSuffix(route, "/")))
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	undocumented, stale = s.spec.Diff(served)
+	return undocumented, stale, nil
+}
+
+// checkSpec logs any drift between the router and the OpenAPI document so
+// a new route without a spec entry shows up the first time the API starts.
+func (s *Server) checkSpec() {
+	undocumented, stale, err := s.UndocumentedRoutes()
+	if err != nil {
+		log.Printf("http: walk routes for openapi check: %v", err)
+		return
+	}
+	for _, route := range undocumented {
+		log.Printf("http: route %s is missing from the openapi document", route)
+	}
+	for _, route := range stale {
+		log.Printf("http: openapi document describes %s, which is not routed", route)
+	}
+}
+
+// buildSpec describes every route in routes(). Keep the two in step;
+// checkSpec reports any route one has and the other lacks.
+func buildSpec() *openapi.Document {
+	b := openapi.NewBuilder("Pickem API", specVersion)
+	ok := func(schema *openapi.Schema) map[string]openapi.Response {
+		return map[string]openapi.Response{"200": openapi.JSONResponse("OK", schema)}
+	}
+	memberQuery := openapi.Query("memberId", "string", false, "")
+	commissionerQuery := openapi.Query("memberId", "string", true, "A commissioner's member ID.")
+	removed := b.Wrap("removed", true)
+
+	b.Add(http.MethodGet, "/healthz", openapi.Operation{
+		OperationID: "getHealth",
+		Responses:   ok(b.Wrap("status", "")),
+	})
+	b.Add(http.MethodGet, "/api/openapi.json", openapi.Operation{
+		OperationID: "getOpenAPI",
+		Summary:     "This document.",
+		Responses:   ok(&openapi.Schema{Type: "object"}),
+	})
+
+	// Seasons.
+	b.Add(http.MethodGet, "/api/seasons", openapi.Operation{
+		OperationID: "listSeasons",
+		Tags:        []string{"seasons"},
+		Responses:   ok(b.Wrap("seasons", []models.Season{})),
+	})
+	b.Add(http.MethodGet, "/api/seasons/{seasonID}/settings", openapi.Operation{
+		OperationID: "getSeasonSettings",
+		Tags:        []string{"seasons"},
+		Responses:   ok(b.Wrap("settings", models.SeasonSettings{})),
+	})
+	b.Add(http.MethodPost, "/api/seasons/{seasonID}/settings", openapi.Operation{
+		OperationID: "updateSeasonSettings",
+		Summary:     "Change any of the season's scoring and auto-pick settings; omitted ones are kept.",
+		Tags:        []string{"seasons"},
+		RequestBody: openapi.JSONBody(&openapi.Schema{Type: "object", Properties: map[string]*openapi.Schema{
+			"tieScoring":    openapi.Enum(models.TieScoringZero, models.TieScoringHalf, models.TieScoringWin),
+			"standingsRule": openapi.Enum(standings.RuleWeeksWon, standings.RuleTotalCorrect, standings.RuleWinPct),
+			"autoPickRule":  openapi.Enum(autopick.RuleNone, autopick.RuleHome, autopick.RuleFavorite, autopick.RuleConsensus, autopick.RuleFadeLeader),
+		}}, true),
+		Responses: ok(b.Wrap("settings", models.SeasonSettings{})),
+	})
+	b.Add(http.MethodGet, "/api/seasons/{seasonID}/export.csv", openapi.Operation{
+		OperationID: "exportSeasonCSV",
+		Tags:        []string{"seasons"},
+		Responses:   map[string]openapi.Response{"200": openapi.TextResponse("The season as a spreadsheet", "text/csv")},
+	})
+	b.Add(http.MethodPost, "/api/seasons/import", openapi.Operation{
+		OperationID: "importSeasonCSV",
+		Summary:     "Load a season from a CSV export. Commissioner only.",
+		Tags:        []string{"seasons"},
+		Parameters: []openapi.Parameter{
+			commissionerQuery,
+			openapi.Query("seasonKey", "string", true, "Provider season key, such as 2024REG."),
+			openapi.Query("label", "string", false, "Overrides the label derived from the season key."),
+			openapi.Query("dryRun", "boolean", false, "Report what would change without saving."),
+		},
+		RequestBody: &openapi.RequestBody{Required: true, Content: map[string]openapi.MediaType{"text/csv": {Schema: &openapi.Schema{Type: "string"}}}},
+		Responses: map[string]openapi.Response{
+			"200": openapi.JSONResponse("OK", b.Wrap("import", models.SeasonImport{})),
+			"422": openapi.JSONResponse("The sheet has problems", b.Object(
+				openapi.Field{Name: "error", Value: ""},
+				openapi.Field{Name: "problems", Value: []seasoncsv.Problem{}},
+			)),
+		},
+	})
+	b.Add(http.MethodGet, "/api/seasons/{seasonID}/calendar.ics", openapi.Operation{
+		OperationID: "getCalendar",
+		Tags:        []string{"feeds"},
+		Parameters:  []openapi.Parameter{openapi.Query("memberId", "string", false, "Personalises the feed with this member's picks.")},
+		Responses:   map[string]openapi.Response{"200": openapi.TextResponse("iCalendar feed", "text/calendar")},
+	})
+	b.Add(http.MethodGet, "/api/seasons/{seasonID}/feed.atom", openapi.Operation{
+		OperationID: "getFeed",
+		Tags:        []string{"feeds"},
+		Responses:   map[string]openapi.Response{"200": openapi.TextResponse("Atom feed of declared results", "application/atom+xml")},
+	})
+
+	// Standings and statistics.
+	b.Add(http.MethodGet, "/api/seasons/{seasonID}/standings", openapi.Operation{
+		OperationID: "getStandings",
+		Tags:        []string{"standings"},
+		Parameters: []openapi.Parameter{
+			openapi.Query("rule", "string", false, "Ranking rule; defaults to the season's."),
+			openapi.Query("week", "integer", false, "Rank through this week; defaults to the latest decided week."),
+		},
+		Responses: ok(b.Wrap("standings", models.Standings{})),
+	})
+	b.Add(http.MethodGet, "/api/seasons/{seasonID}/ratings", openapi.Operation{
+		OperationID: "getRatings",
+		Tags:        []string{"standings"},
+		Responses:   ok(b.Wrap("ratings", models.RatingReport{})),
+	})
+	b.Add(http.MethodGet, "/api/seasons/{seasonID}/members/{memberID}/stats", openapi.Operation{
+		OperationID: "getMemberStats",
+		Tags:        []string{"standings"},
+		Responses:   ok(b.Wrap("stats", models.MemberStats{})),
+	})
+	b.Add(http.MethodGet, "/api/seasons/{seasonID}/members/{memberID}/head-to-head/{opponentID}", openapi.Operation{
+		OperationID: "getSeasonHeadToHead",
+		Tags:        []string{"standings"},
+		Responses:   ok(b.Wrap("headToHead", models.HeadToHead{})),
+	})
+	b.Add(http.MethodGet, "/api/members/{memberID}/head-to-head/{opponentID}", openapi.Operation{
+		OperationID: "getHeadToHead",
+		Summary:     "All-time head-to-head record.",
+		Tags:        []string{"standings"},
+		Responses:   ok(b.Wrap("headToHead", models.HeadToHead{})),
+	})
+	b.Add(http.MethodGet, "/api/hall-of-fame", openapi.Operation{
+		OperationID: "getHallOfFame",
+		Tags:        []string{"standings"},
+		Responses:   ok(b.Wrap("hallOfFame", models.HallOfFame{})),
+	})
+
+	// Archive.
+	b.Add(http.MethodGet, "/api/archive", openapi.Operation{
+		OperationID: "exportArchive",
+		Summary:     "Download the pool as a JSON archive. Commissioner only.",
+		Tags:        []string{"archive"},
+		Parameters:  []openapi.Parameter{commissionerQuery, openapi.Query("seasonId", "string", false, "Export only this season.")},
+		Responses:   ok(b.Schema(archive.Archive{})),
+	})
+	b.Add(http.MethodPost, "/api/archive/restore", openapi.Operation{
+		OperationID: "restoreArchive",
+		Summary:     "Load a JSON archive. Commissioner only.",
+		Tags:        []string{"archive"},
+		Parameters:  []openapi.Parameter{commissionerQuery},
+		RequestBody: openapi.JSONBody(b.Schema(archive.Archive{}), true),
+		Responses:   ok(b.Wrap("restore", models.ArchiveRestore{})),
+	})
+
+	// Webhooks.
+	b.Add(http.MethodGet, "/api/webhooks", openapi.Operation{
+		OperationID: "listWebhooks",
+		Tags:        []string{"webhooks"},
+		Parameters:  []openapi.Parameter{commissionerQuery},
+		Responses:   ok(b.Wrap("webhooks", []models.Webhook{})),
+	})
+	b.Add(http.MethodPost, "/api/webhooks", openapi.Operation{
+		OperationID: "createWebhook",
+		Tags:        []string{"webhooks"},
+		RequestBody: openapi.JSONBody(b.Schema(createWebhookRequest{}), true),
+		Responses:   map[string]openapi.Response{"201": openapi.JSONResponse("Created", b.Wrap("webhook", models.Webhook{}))},
+	})
+	b.Add(http.MethodDelete, "/api/webhooks/{webhookID}", openapi.Operation{
+		OperationID: "deleteWebhook",
+		Tags:        []string{"webhooks"},
+		Parameters:  []openapi.Parameter{commissionerQuery},
+		Responses:   ok(removed),
+	})
+	b.Add(http.MethodGet, "/api/webhooks/{webhookID}/deliveries", openapi.Operation{
+		OperationID: "listWebhookDeliveries",
+		Tags:        []string{"webhooks"},
+		Parameters:  []openapi.Parameter{commissionerQuery, openapi.Query("limit", "integer", false, "")},
+		Responses:   ok(b.Wrap("deliveries", []models.WebhookDelivery{})),
+	})
+
+	// Weeks.
+	b.Add(http.MethodGet, "/api/seasons/{seasonID}/weeks", openapi.Operation{
+		OperationID: "listSeasonWeeks",
+		Tags:        []string{"weeks"},
+		Responses:   ok(b.Wrap("weeks", []models.Week{})),
+	})
+	b.Add(http.MethodGet, "/api/seasons/{seasonID}/weeks/current", openapi.Operation{
+		OperationID: "getCurrentWeek",
+		Tags:        []string{"weeks"},
+		Responses:   ok(b.Wrap("weekNumber", 0)),
+	})
+	b.Add(http.MethodGet, "/api/seasons/{seasonID}/weeks/{weekNumber}", openapi.Operation{
+		OperationID: "getPageData",
+		Summary:     "Everything the week page shows.",
+		Tags:        []string{"weeks"},
+		Responses:   ok(b.Schema(models.PageData{})),
+	})
+	b.Add(http.MethodGet, "/api/seasons/{seasonID}/weeks/{weekNumber}/contenders", openapi.Operation{
+		OperationID: "getContenders",
+		Tags:        []string{"weeks"},
+		Parameters:  []openapi.Parameter{openapi.Query("allowTies", "boolean", false, "Count a shared week as still alive; defaults to true.")},
+		Responses:   ok(b.Wrap("contenders", models.ContenderReport{})),
+	})
+	b.Add(http.MethodGet, "/api/seasons/{seasonID}/weeks/{weekNumber}/probabilities", openapi.Operation{
+		OperationID: "getProbabilities",
+		Summary:     "Simulate each member's chance of winning the week and the season. Runs of at least the default size also feed the page data's member probabilities.",
+		Tags:        []string{"weeks"},
+		Parameters: []openapi.Parameter{
+			openapi.Query("iterations", "integer", false, "Simulated seasons, up to 200000."),
+			openapi.Query("seed", "integer", false, "Fixes the simulation for repeatable results."),
+		},
+		Responses: ok(b.Wrap("probabilities", models.ProbabilityReport{})),
+	})
+	b.Add(http.MethodGet, "/api/seasons/{seasonID}/weeks/{weekNumber}/recap", openapi.Operation{
+		OperationID: "getRecap",
+		Tags:        []string{"weeks"},
+		Parameters: []openapi.Parameter{{
+			Name: "format", In: "query",
+			Description: "Defaults to markdown.",
+			Schema:      openapi.Enum(recap.FormatMarkdown, recap.FormatText, "json"),
+		}},
+		Responses: map[string]openapi.Response{"200": func() openapi.Response {
+			out := openapi.TextResponse("The recap in the requested format", "text/markdown", "text/plain")
+			out.Content["application/json"] = openapi.MediaType{Schema: b.Wrap("recap", models.WeekRecap{})}
+			return out
+		}()},
+	})
+	b.Add(http.MethodPost, "/api/seasons/{seasonID}/weeks/{weekNumber}/sync", openapi.Operation{
+		OperationID: "syncWeek",
+		Summary:     "Pull the week's games from the sports data provider.",
+		Tags:        []string{"weeks"},
+		RequestBody: openapi.JSONBody(b.Schema(syncRequest{}), false),
+		Responses: ok(b.Object(
+			openapi.Field{Name: "syncedGames", Value: 0},
+			openapi.Field{Name: "dryRun", Value: false},
+			openapi.Field{Name: "games", Value: []models.SyncGameDiff{}},
+			openapi.Field{Name: "unchanged", Value: 0},
+			openapi.Field{Name: "conflicts", Value: []models.SyncConflict{}},
+		)),
+	})
+
+	// Picks.
+	b.Add(http.MethodPost, "/api/seasons/{seasonID}/weeks/{weekNumber}/picks", openapi.Operation{
+		OperationID: "upsertPick",
+		Tags:        []string{"picks"},
+		RequestBody: openapi.JSONBody(b.Schema(pickRequest{}), true),
+		Responses:   ok(b.Wrap("pick", models.GamePick{})),
+	})
+	b.Add(http.MethodDelete, "/api/seasons/{seasonID}/weeks/{weekNumber}/picks", openapi.Operation{
+		OperationID: "deletePick",
+		Summary:     "Remove a pick, named in the body or the query string.",
+		Tags:        []string{"picks"},
+		Parameters: []openapi.Parameter{
+			memberQuery,
+			openapi.Query("gameKey", "string", false, ""),
+			openapi.Query("enteredBy", "string", false, ""),
+		},
+		RequestBody: openapi.JSONBody(b.Schema(deletePickRequest{}), false),
+		Responses:   ok(removed),
+	})
+	b.Add(http.MethodGet, "/api/seasons/{seasonID}/weeks/{weekNumber}/picks/history", openapi.Operation{
+		OperationID: "listPickHistory",
+		Tags:        []string{"picks"},
+		Parameters:  []openapi.Parameter{memberQuery},
+		Responses:   ok(b.Wrap("history", []models.PickHistoryEntry{})),
+	})
+	b.Add(http.MethodPost, "/api/seasons/{seasonID}/weeks/{weekNumber}/picks/parse", openapi.Operation{
+		OperationID: "parsePicks",
+		Summary:     "Read picks from a texted message, and save them with commit.",
+		Tags:        []string{"picks"},
+		RequestBody: openapi.JSONBody(b.Schema(parsePicksRequest{}), true),
+		Responses: map[string]openapi.Response{
+			"200": openapi.JSONResponse("OK", b.Wrap("result", models.PickParseResult{})),
+			"422": openapi.JSONResponse("The message has unresolved issues", b.Object(
+				openapi.Field{Name: "error", Value: ""},
+				openapi.Field{Name: "result", Value: models.PickParseResult{}},
+			)),
+		},
+	})
+	b.Add(http.MethodPost, "/api/seasons/{seasonID}/weeks/{weekNumber}/tie-breaker", openapi.Operation{
+		OperationID: "upsertTieBreaker",
+		Tags:        []string{"picks"},
+		RequestBody: openapi.JSONBody(b.Schema(tieBreakerRequest{}), true),
+		Responses: ok(b.Wrap("tieBreaker", b.Object(
+			openapi.Field{Name: "memberId", Value: ""},
+			openapi.Field{Name: "weekNumber", Value: 0},
+			openapi.Field{Name: "points", Value: 0},
+		))),
+	})
+
+	// Results.
+	b.Add(http.MethodPost, "/api/seasons/{seasonID}/weeks/{weekNumber}/games/{gameKey}/winner", openapi.Operation{
+		OperationID: "setGameWinner",
+		Summary:     "Override a game's result; a null winner clears the override.",
+		Tags:        []string{"results"},
+		RequestBody: openapi.JSONBody(b.Schema(setGameWinnerRequest{}), true),
+		Responses:   ok(b.Wrap("game", models.Game{})),
+	})
+	b.Add(http.MethodPost, "/api/seasons/{seasonID}/weeks/{weekNumber}/games/{gameKey}/unlock", openapi.Operation{
+		OperationID: "unlockGame",
+		Tags:        []string{"results"},
+		Responses:   ok(b.Wrap("game", models.Game{})),
+	})
+	b.Add(http.MethodPost, "/api/seasons/{seasonID}/weeks/{weekNumber}/winner", openapi.Operation{
+		OperationID: "declareWinner",
+		Tags:        []string{"results"},
+		RequestBody: openapi.JSONBody(b.Schema(declareWinnerRequest{}), true),
+		Responses:   ok(b.Wrap("weekResult", models.WeekResult{})),
+	})
+
+	return b.Document()
+}
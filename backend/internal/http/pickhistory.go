@@ -0,0 +1,42 @@
+package httpapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"pickem/backend/internal/store"
+)
+
+// allowProxyEntry writes the error response and returns false when
+// enteredBy names someone other than the member who is not a commissioner.
+// Only the commissioner may enter picks on another member's behalf.
+func (s *Server) allowProxyEntry(ctx context.Context, w http.ResponseWriter, memberID, enteredBy string) bool {
+	enteredBy = strings.TrimSpace(enteredBy)
+	if enteredBy == "" || enteredBy == memberID {
+		return true
+	}
+	return s.requireCommissioner(ctx, w, enteredBy)
+}
+
+func (s *Server) handleListPickHistory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	seasonID, weekNumber, err := parseSeasonWeekParams(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	history, err := s.store.ListPickHistory(ctx, seasonID, weekNumber, r.URL.Query().Get("memberId"))
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, store.ErrSeasonNotFound) || errors.Is(err, store.ErrWeekNotFound) {
+			status = http.StatusNotFound
+		}
+		writeError(w, status, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"history": history})
+}
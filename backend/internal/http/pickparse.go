@@ -0,0 +1,89 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"pickem/backend/internal/models"
+	"pickem/backend/internal/store"
+)
+
+type parsePicksRequest struct {
+	MemberID  string `json:"memberId"`
+	Message   string `json:"message"`
+	EnteredBy string `json:"enteredBy,omitempty"`
+	Commit    bool   `json:"commit,omitempty"`
+}
+
+// handleParsePicks turns a texted pick message into picks. Without commit it
+// only returns the preview. With commit the picks (and tie-breaker, if the
+// message had one) are saved together, the same way as POST /picks, but only
+// when the preview has no issues left to resolve.
+func (s *Server) handleParsePicks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	seasonID, weekNumber, err := parseSeasonWeekParams(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var req parsePicksRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	req.MemberID = strings.TrimSpace(req.MemberID)
+	req.EnteredBy = strings.TrimSpace(req.EnteredBy)
+	if req.MemberID == "" || strings.TrimSpace(req.Message) == "" {
+		writeError(w, http.StatusBadRequest, errors.New("memberId and message are required"))
+		return
+	}
+	if !s.allowProxyEntry(ctx, w, req.MemberID, req.EnteredBy) {
+		return
+	}
+
+	result, err := s.store.ParsePickMessage(ctx, seasonID, weekNumber, req.MemberID, req.Message)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, store.ErrSeasonNotFound) || errors.Is(err, store.ErrWeekNotFound) || errors.Is(err, store.ErrMemberNotFound) {
+			status = http.StatusNotFound
+		}
+		writeError(w, status, err)
+		return
+	}
+
+	if !req.Commit {
+		writeJSON(w, http.StatusOK, map[string]any{"result": result})
+		return
+	}
+	if len(result.Issues) > 0 {
+		writeJSON(w, http.StatusUnprocessableEntity, map[string]any{
+			"error":  "resolve the issues in the message before committing",
+			"result": result,
+		})
+		return
+	}
+
+	picks, err := s.store.CommitPickMessage(ctx, seasonID, weekNumber, result, req.EnteredBy)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, store.ErrPickLocked) {
+			status = http.StatusConflict
+		}
+		writeError(w, status, err)
+		return
+	}
+	for i, pick := range picks {
+		s.webhooks.Fire(models.WebhookEventPickSaved, map[string]any{
+			"seasonId":   seasonID,
+			"weekNumber": weekNumber,
+			"gameKey":    result.Picks[i].GameKey,
+			"pick":       pick,
+		})
+	}
+
+	result.Committed = true
+	writeJSON(w, http.StatusOK, map[string]any{"result": result})
+}
@@ -0,0 +1,141 @@
+package httpapi
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"pickem/backend/internal/models"
+	"pickem/backend/internal/simulation"
+	"pickem/backend/internal/store"
+)
+
+// probabilitiesTTL is how long a simulated report is shown on the page data
+// before the next load runs the simulation again.
+const probabilitiesTTL = 5 * time.Minute
+
+func (s *Server) handleGetProbabilities(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	seasonID, weekNumber, err := parseSeasonWeekParams(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	iterations := simulation.DefaultIterations
+	if raw := strings.TrimSpace(r.URL.Query().Get("iterations")); raw != "" {
+		value, err := strconv.Atoi(raw)
+		if err != nil || value <= 0 || value > simulation.MaxIterations {
+			writeError(w, http.StatusBadRequest, errors.New("iterations must be between 1 and 200000"))
+			return
+		}
+		iterations = value
+	}
+
+	seed := time.Now().UnixNano()
+	if raw := strings.TrimSpace(r.URL.Query().Get("seed")); raw != "" {
+		value, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, errors.New("seed must be an integer"))
+			return
+		}
+		seed = value
+	}
+
+	report, err := s.store.GetWinProbabilities(ctx, seasonID, weekNumber, iterations, rand.New(rand.NewSource(seed)))
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, store.ErrSeasonNotFound) || errors.Is(err, store.ErrWeekNotFound) {
+			status = http.StatusNotFound
+		}
+		writeError(w, status, err)
+		return
+	}
+	// Smaller runs are too noisy to show on the page.
+	if report.Iterations >= simulation.DefaultIterations {
+		s.probabilities.put(report, time.Now())
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"probabilities": report})
+}
+
+// attachProbabilities fills in each member's win probabilities on the page
+// data from the last simulated report, running the simulation only when
+// there is none or it has expired. Failures only cost the percentages, so
+// they are logged and skipped.
+func (s *Server) attachProbabilities(ctx context.Context, data *models.PageData) {
+	now := time.Now()
+	report, ok := s.probabilities.get(data.Season.ID, data.ActiveWeek.Number, now)
+	if !ok {
+		var err error
+		rng := rand.New(rand.NewSource(now.UnixNano()))
+		report, err = s.store.GetWinProbabilities(ctx, data.Season.ID, data.ActiveWeek.Number, simulation.DefaultIterations, rng)
+		if err != nil {
+			log.Printf("http: win probabilities failed for season %s week %d: %v", data.Season.ID, data.ActiveWeek.Number, err)
+			return
+		}
+		s.probabilities.put(report, now)
+	}
+
+	byMember := map[string]models.MemberProbability{}
+	for _, probability := range report.Members {
+		byMember[probability.MemberID] = probability
+	}
+	for i := range data.Members {
+		if probability, ok := byMember[data.Members[i].ID]; ok {
+			week, season := probability.Week, probability.Season
+			data.Members[i].WeekWinProbability = &week
+			data.Members[i].SeasonWinProbability = &season
+		}
+	}
+}
+
+// probabilityCache keeps the last simulated report for each season week.
+type probabilityCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	reports map[probabilityKey]cachedProbabilities
+}
+
+type probabilityKey struct {
+	seasonID   string
+	weekNumber int
+}
+
+type cachedProbabilities struct {
+	report     *models.ProbabilityReport
+	computedAt time.Time
+}
+
+func newProbabilityCache(ttl time.Duration) *probabilityCache {
+	return &probabilityCache{ttl: ttl, reports: map[probabilityKey]cachedProbabilities{}}
+}
+
+func (c *probabilityCache) get(seasonID string, weekNumber int, now time.Time) (*models.ProbabilityReport, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cached, ok := c.reports[probabilityKey{seasonID, weekNumber}]
+	if !ok || now.Sub(cached.computedAt) >= c.ttl {
+		return nil, false
+	}
+	return cached.report, true
+}
+
+func (c *probabilityCache) put(report *models.ProbabilityReport, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.reports[probabilityKey{report.SeasonID, report.WeekNumber}] = cachedProbabilities{report: report, computedAt: now}
+}
+
+// reset drops every report, for when results change under them.
+func (c *probabilityCache) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.reports = map[probabilityKey]cachedProbabilities{}
+}
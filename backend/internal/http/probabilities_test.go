@@ -0,0 +1,34 @@
+package httpapi
+
+import (
+	"testing"
+	"time"
+
+	"pickem/backend/internal/models"
+)
+
+func TestProbabilityCache(t *testing.T) {
+	cache := newProbabilityCache(time.Minute)
+	now := time.Date(2024, 9, 8, 18, 0, 0, 0, time.UTC)
+	report := &models.ProbabilityReport{SeasonID: "s1", WeekNumber: 2}
+
+	if _, ok := cache.get("s1", 2, now); ok {
+		t.Fatal("empty cache returned a report")
+	}
+	cache.put(report, now)
+
+	if got, ok := cache.get("s1", 2, now.Add(30*time.Second)); !ok || got != report {
+		t.Errorf("get within ttl = %v, %v", got, ok)
+	}
+	if _, ok := cache.get("s1", 3, now); ok {
+		t.Error("report leaked to another week")
+	}
+	if _, ok := cache.get("s1", 2, now.Add(time.Minute)); ok {
+		t.Error("expired report returned")
+	}
+
+	cache.reset()
+	if _, ok := cache.get("s1", 2, now); ok {
+		t.Error("report survived reset")
+	}
+}
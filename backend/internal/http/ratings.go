@@ -0,0 +1,77 @@
+package httpapi
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"pickem/backend/internal/store"
+)
+
+const ratingsRefreshTimeout = 2 * time.Minute
+
+func (s *Server) handleGetRatings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	seasonID := chi.URLParam(r, "seasonID")
+	if strings.TrimSpace(seasonID) == "" {
+		writeError(w, http.StatusBadRequest, errors.New("seasonID is required"))
+		return
+	}
+
+	ratings, err := s.store.GetRatings(ctx, seasonID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, store.ErrSeasonNotFound) {
+			status = http.StatusNotFound
+		}
+		writeError(w, status, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"ratings": ratings})
+}
+
+// ratingsRefresher rebuilds the rating history in the background. Requests
+// made while a rebuild runs collapse into one more rebuild afterwards, so a
+// burst of result changes costs at most two.
+type ratingsRefresher struct {
+	rebuild func(context.Context) error
+	pending chan struct{}
+	start   sync.Once
+}
+
+func newRatingsRefresher(st *store.Store) *ratingsRefresher {
+	r := &ratingsRefresher{pending: make(chan struct{}, 1)}
+	if st != nil {
+		r.rebuild = st.RecomputeRatings
+	}
+	return r
+}
+
+// Request schedules a rebuild and returns straight away.
+func (r *ratingsRefresher) Request() {
+	if r == nil || r.rebuild == nil {
+		return
+	}
+	r.start.Do(func() { go r.loop() })
+	select {
+	case r.pending <- struct{}{}:
+	default:
+	}
+}
+
+func (r *ratingsRefresher) loop() {
+	for range r.pending {
+		ctx, cancel := context.WithTimeout(context.Background(), ratingsRefreshTimeout)
+		if err := r.rebuild(ctx); err != nil {
+			log.Printf("http: recompute ratings: %v", err)
+		}
+		cancel()
+	}
+}
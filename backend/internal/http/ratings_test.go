@@ -0,0 +1,47 @@
+package httpapi
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRatingsRefresherCoalescesRequests(t *testing.T) {
+	var runs atomic.Int32
+	started := make(chan struct{}, 10)
+	release := make(chan struct{})
+	r := &ratingsRefresher{pending: make(chan struct{}, 1)}
+	r.rebuild = func(context.Context) error {
+		runs.Add(1)
+		started <- struct{}{}
+		<-release
+		return nil
+	}
+
+	r.Request()
+	<-started
+	// The first rebuild is running; everything asked for meanwhile folds
+	// into a single follow-up.
+	for i := 0; i < 5; i++ {
+		r.Request()
+	}
+	release <- struct{}{}
+	<-started
+	release <- struct{}{}
+
+	select {
+	case <-started:
+		t.Fatal("more than one follow-up rebuild ran")
+	case <-time.After(50 * time.Millisecond):
+	}
+	if got := runs.Load(); got != 2 {
+		t.Fatalf("rebuilds = %d, want 2", got)
+	}
+}
+
+func TestRatingsRefresherWithoutStore(t *testing.T) {
+	newRatingsRefresher(nil).Request()
+	var r *ratingsRefresher
+	r.Request()
+}
@@ -0,0 +1,61 @@
+package httpapi
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"strings"
+
+	"pickem/backend/internal/recap"
+	"pickem/backend/internal/store"
+)
+
+// handleGetRecap returns the week's recap as Markdown by default; format=text
+// gives plain text for pasting into chats and format=json the structured
+// summary.
+func (s *Server) handleGetRecap(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	seasonID, weekNumber, err := parseSeasonWeekParams(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	format := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("format")))
+	if format == "" {
+		format = recap.FormatMarkdown
+	}
+	if format != "json" && format != recap.FormatMarkdown && format != recap.FormatText {
+		writeError(w, http.StatusBadRequest, errors.New("format must be markdown, text or json"))
+		return
+	}
+
+	weekRecap, err := s.store.GetWeekRecap(ctx, seasonID, weekNumber)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, store.ErrSeasonNotFound) || errors.Is(err, store.ErrWeekNotFound) {
+			status = http.StatusNotFound
+		}
+		writeError(w, status, err)
+		return
+	}
+
+	if format == "json" {
+		writeJSON(w, http.StatusOK, map[string]any{"recap": weekRecap})
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := recap.Render(&buf, *weekRecap, format); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	contentType := "text/markdown; charset=utf-8"
+	if format == recap.FormatText {
+		contentType = "text/plain; charset=utf-8"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(buf.Bytes())
+}
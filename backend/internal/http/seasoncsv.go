@@ -0,0 +1,123 @@
+package httpapi
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"pickem/backend/internal/bootstrap"
+	"pickem/backend/internal/models"
+	"pickem/backend/internal/seasoncsv"
+	"pickem/backend/internal/store"
+)
+
+// maxImportBytes caps uploaded spreadsheets; a full season is well under
+// a megabyte.
+const maxImportBytes = 8 << 20
+
+func (s *Server) handleExportSeasonCSV(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	seasonID := chi.URLParam(r, "seasonID")
+	if strings.TrimSpace(seasonID) == "" {
+		writeError(w, http.StatusBadRequest, errors.New("seasonID is required"))
+		return
+	}
+
+	season, err := s.store.GetSeason(ctx, seasonID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, store.ErrSeasonNotFound) {
+			status = http.StatusNotFound
+		}
+		writeError(w, status, err)
+		return
+	}
+
+	sheet, err := s.store.ExportSeasonSheet(ctx, seasonID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.csv"`, season.SportsDataSeasonKey))
+	if err := seasoncsv.Write(w, *sheet); err != nil {
+		log.Printf("http: export season %s: %v", seasonID, err)
+	}
+}
+
+// handleImportSeasonCSV loads a season from a spreadsheet export, either
+// this app's own or one kept by hand before it existed. The body is the CSV
+// itself. Only the commissioner may import, and dryRun=true reports what
+// would change without saving anything.
+func (s *Server) handleImportSeasonCSV(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query := r.URL.Query()
+	if !s.requireCommissioner(ctx, w, query.Get("memberId")) {
+		return
+	}
+
+	seasonKey := strings.ToUpper(strings.TrimSpace(query.Get("seasonKey")))
+	if seasonKey == "" {
+		writeError(w, http.StatusBadRequest, errors.New("seasonKey is required"))
+		return
+	}
+	year, label, err := bootstrap.SeasonLabel(seasonKey)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if custom := strings.TrimSpace(query.Get("label")); custom != "" {
+		label = custom
+	}
+
+	sheet, err := seasoncsv.Read(http.MaxBytesReader(w, r.Body, maxImportBytes))
+	if err != nil {
+		var invalid *seasoncsv.ValidationError
+		if errors.As(err, &invalid) {
+			writeJSON(w, http.StatusUnprocessableEntity, map[string]any{
+				"error":    err.Error(),
+				"problems": invalid.Problems,
+			})
+			return
+		}
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	dryRun := query.Get("dryRun") == "true"
+	report, err := s.store.ImportSeasonSheet(ctx, models.Season{
+		Label:               label,
+		Year:                year,
+		SportsDataSeasonKey: seasonKey,
+	}, sheet, dryRun)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, store.ErrImportConflict) {
+			status = http.StatusConflict
+		}
+		writeError(w, status, err)
+		return
+	}
+
+	if !dryRun {
+		weeks, err := s.store.ListSeasonWeeks(ctx, report.SeasonID)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		for _, week := range weeks {
+			if err := s.store.RefreshGameTags(ctx, week.ID); err != nil {
+				log.Printf("http: refresh game tags for week %s: %v", week.ID, err)
+			}
+		}
+		s.ratings.Request()
+		s.probabilities.reset()
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"import": report})
+}
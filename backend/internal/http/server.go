@@ -17,14 +17,20 @@ import (
 
 	"pickem/backend/internal/config"
 	"pickem/backend/internal/models"
+	"pickem/backend/internal/openapi"
 	"pickem/backend/internal/store"
+	"pickem/backend/internal/webhooks"
 	"pickem/backend/sportsdata"
 )
 
 type Server struct {
-	cfg    config.Config
-	store  *store.Store
-	router chi.Router
+	cfg           config.Config
+	store         *store.Store
+	webhooks      *webhooks.Dispatcher
+	ratings       *ratingsRefresher
+	probabilities *probabilityCache
+	router        chi.Router
+	spec          *openapi.Document
 }
 
 var (
@@ -32,11 +38,18 @@ var (
 	errSportsDataUnavailable = errors.New("sports data unavailable")
 )
 
-func New(cfg config.Config, store *store.Store) *Server {
+// New builds the API server. dispatcher delivers webhook events; share it
+// with anything else that fires them so every delivery goes through one
+// place.
+func New(cfg config.Config, store *store.Store, dispatcher *webhooks.Dispatcher) *Server {
 	s := &Server{
-		cfg:    cfg,
-		store:  store,
-		router: chi.NewRouter(),
+		cfg:           cfg,
+		store:         store,
+		webhooks:      dispatcher,
+		ratings:       newRatingsRefresher(store),
+		probabilities: newProbabilityCache(probabilitiesTTL),
+		router:        chi.NewRouter(),
+		spec:          buildSpec(),
 	}
 
 	s.router.Use(middleware.RequestID)
@@ -63,6 +76,7 @@ func New(cfg config.Config, store *store.Store) *Server {
 	}
 
 	s.routes()
+	s.checkSpec()
 
 	return s
 }
@@ -74,14 +88,39 @@ func (s *Server) Handler() http.Handler {
 func (s *Server) routes() {
 	s.router.Get("/healthz", s.handleHealth)
 	s.router.Route("/api", func(r chi.Router) {
+		r.Get("/openapi.json", s.handleGetOpenAPI)
 		r.Get("/seasons", s.handleListSeasons)
+		r.Get("/seasons/{seasonID}/settings", s.handleGetSeasonSettings)
+		r.Get("/seasons/{seasonID}/export.csv", s.handleExportSeasonCSV)
+		r.Get("/seasons/{seasonID}/calendar.ics", s.handleGetCalendar)
+		r.Get("/seasons/{seasonID}/feed.atom", s.handleGetFeed)
+		r.Post("/seasons/import", s.handleImportSeasonCSV)
+		r.Post("/seasons/{seasonID}/settings", s.handleUpdateSeasonSettings)
+		r.Get("/seasons/{seasonID}/standings", s.handleGetStandings)
+		r.Get("/seasons/{seasonID}/ratings", s.handleGetRatings)
+		r.Get("/seasons/{seasonID}/members/{memberID}/stats", s.handleGetMemberStats)
+		r.Get("/seasons/{seasonID}/members/{memberID}/head-to-head/{opponentID}", s.handleGetHeadToHead)
+		r.Get("/members/{memberID}/head-to-head/{opponentID}", s.handleGetHeadToHead)
+		r.Get("/hall-of-fame", s.handleGetHallOfFame)
+		r.Get("/archive", s.handleExportArchive)
+		r.Post("/archive/restore", s.handleRestoreArchive)
+		r.Get("/webhooks", s.handleListWebhooks)
+		r.Post("/webhooks", s.handleCreateWebhook)
+		r.Delete("/webhooks/{webhookID}", s.handleDeleteWebhook)
+		r.Get("/webhooks/{webhookID}/deliveries", s.handleListWebhookDeliveries)
 		r.Get("/seasons/{seasonID}/weeks", s.handleListSeasonWeeks)
 		r.Get("/seasons/{seasonID}/weeks/current", s.handleGetCurrentWeek)
 		r.Get("/seasons/{seasonID}/weeks/{weekNumber}", s.handleGetPageData)
+		r.Get("/seasons/{seasonID}/weeks/{weekNumber}/contenders", s.handleGetContenders)
+		r.Get("/seasons/{seasonID}/weeks/{weekNumber}/probabilities", s.handleGetProbabilities)
+		r.Get("/seasons/{seasonID}/weeks/{weekNumber}/recap", s.handleGetRecap)
 		r.Post("/seasons/{seasonID}/weeks/{weekNumber}/picks", s.handleUpsertPick)
 		r.Delete("/seasons/{seasonID}/weeks/{weekNumber}/picks", s.handleDeletePick)
+		r.Get("/seasons/{seasonID}/weeks/{weekNumber}/picks/history", s.handleListPickHistory)
+		r.Post("/seasons/{seasonID}/weeks/{weekNumber}/picks/parse", s.handleParsePicks)
 		r.Post("/seasons/{seasonID}/weeks/{weekNumber}/tie-breaker", s.handleUpsertTieBreaker)
 		r.Post("/seasons/{seasonID}/weeks/{weekNumber}/games/{gameKey}/winner", s.handleSetGameWinner)
+		r.Post("/seasons/{seasonID}/weeks/{weekNumber}/games/{gameKey}/unlock", s.handleUnlockGame)
 		r.Post("/seasons/{seasonID}/weeks/{weekNumber}/winner", s.handleDeclareWinner)
 		r.Post("/seasons/{seasonID}/weeks/{weekNumber}/sync", s.handleSyncWeek)
 	})
@@ -101,6 +140,61 @@ func (s *Server) handleListSeasons(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, map[string]any{"seasons": seasons})
 }
 
+func (s *Server) handleGetSeasonSettings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	seasonID := chi.URLParam(r, "seasonID")
+	if strings.TrimSpace(seasonID) == "" {
+		writeError(w, http.StatusBadRequest, errors.New("seasonID is required"))
+		return
+	}
+
+	settings, err := s.store.GetSeasonSettings(ctx, seasonID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, store.ErrSeasonNotFound) {
+			status = http.StatusNotFound
+		}
+		writeError(w, status, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"settings": settings})
+}
+
+func (s *Server) handleUpdateSeasonSettings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	seasonID := chi.URLParam(r, "seasonID")
+	if strings.TrimSpace(seasonID) == "" {
+		writeError(w, http.StatusBadRequest, errors.New("seasonID is required"))
+		return
+	}
+
+	var req seasonSettingsRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	settings, err := s.store.UpdateSeasonSettings(ctx, seasonID, store.SeasonSettingsUpdate{
+		TieScoring:    req.TieScoring,
+		StandingsRule: req.StandingsRule,
+		AutoPickRule:  req.AutoPickRule,
+	})
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, store.ErrSeasonNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, store.ErrInvalidSettings):
+			status = http.StatusBadRequest
+		}
+		writeError(w, status, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"settings": settings})
+}
+
 func (s *Server) handleListSeasonWeeks(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	seasonID := chi.URLParam(r, "seasonID")
@@ -166,7 +260,7 @@ func (s *Server) handleGetPageData(w http.ResponseWriter, r *http.Request) {
 	if len(data.Games) == 0 && s.cfg.EnableSportsSync && s.cfg.SportsAPIKey != "" {
 		season := data.Season
 		week := data.ActiveWeek
-		snapshots, syncErr := s.syncWeek(ctx, &season, &week)
+		snapshots, _, syncErr := s.syncWeek(ctx, &season, &week, store.SyncOptions{})
 		if syncErr != nil && !errors.Is(syncErr, errSportsAPIKeyMissing) {
 			log.Printf("http: automatic sync failed for season %s week %d: %v", seasonID, week.Number, syncErr)
 		}
@@ -179,6 +273,8 @@ func (s *Server) handleGetPageData(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	s.attachProbabilities(ctx, data)
+
 	writeJSON(w, http.StatusOK, data)
 }
 
@@ -208,17 +304,31 @@ func (s *Server) handleUpsertPick(w http.ResponseWriter, r *http.Request) {
 	req.MemberID = strings.TrimSpace(req.MemberID)
 	req.GameKey = strings.TrimSpace(req.GameKey)
 	req.Side = strings.ToLower(strings.TrimSpace(req.Side))
+	req.EnteredBy = strings.TrimSpace(req.EnteredBy)
 
 	if req.MemberID == "" || req.GameKey == "" || req.Side == "" {
 		writeError(w, http.StatusBadRequest, errors.New("memberId, gameKey, and side are required"))
 		return
 	}
+	if !s.allowProxyEntry(ctx, w, req.MemberID, req.EnteredBy) {
+		return
+	}
 
-	pick, err := s.store.UpsertPick(ctx, req.MemberID, req.GameKey, req.Side)
+	pick, err := s.store.UpsertPick(ctx, req.MemberID, req.GameKey, req.Side, req.EnteredBy)
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, err)
+		status := http.StatusInternalServerError
+		if errors.Is(err, store.ErrPickLocked) {
+			status = http.StatusConflict
+		}
+		writeError(w, status, err)
 		return
 	}
+	s.webhooks.Fire(models.WebhookEventPickSaved, map[string]any{
+		"seasonId":   seasonID,
+		"weekNumber": weekNumber,
+		"gameKey":    req.GameKey,
+		"pick":       pick,
+	})
 
 	writeJSON(w, http.StatusOK, map[string]any{"pick": pick})
 }
@@ -254,19 +364,37 @@ func (s *Server) handleDeletePick(w http.ResponseWriter, r *http.Request) {
 	if req.GameKey == "" {
 		req.GameKey = r.URL.Query().Get("gameKey")
 	}
+	if req.EnteredBy == "" {
+		req.EnteredBy = r.URL.Query().Get("enteredBy")
+	}
 
 	req.MemberID = strings.TrimSpace(req.MemberID)
 	req.GameKey = strings.TrimSpace(req.GameKey)
+	req.EnteredBy = strings.TrimSpace(req.EnteredBy)
 
 	if req.MemberID == "" || req.GameKey == "" {
 		writeError(w, http.StatusBadRequest, errors.New("memberId and gameKey are required"))
 		return
 	}
+	if !s.allowProxyEntry(ctx, w, req.MemberID, req.EnteredBy) {
+		return
+	}
 
-	if err := s.store.DeletePick(ctx, req.MemberID, req.GameKey); err != nil {
-		writeError(w, http.StatusInternalServerError, err)
+	if err := s.store.DeletePick(ctx, req.MemberID, req.GameKey, req.EnteredBy); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, store.ErrPickLocked) {
+			status = http.StatusConflict
+		}
+		writeError(w, status, err)
 		return
 	}
+	s.webhooks.Fire(models.WebhookEventPickDeleted, map[string]any{
+		"seasonId":   seasonID,
+		"weekNumber": weekNumber,
+		"gameKey":    req.GameKey,
+		"memberId":   req.MemberID,
+		"enteredBy":  req.EnteredBy,
+	})
 
 	writeJSON(w, http.StatusOK, map[string]any{"removed": true})
 }
@@ -351,7 +479,50 @@ func (s *Server) handleSetGameWinner(w http.ResponseWriter, r *http.Request) {
 		winner = *req.Winner
 	}
 
-	game, err := s.store.UpdateGameWinner(ctx, week.ID, gameKey, winner)
+	game, err := s.store.UpdateGameWinner(ctx, week.ID, gameKey, winner, req.Reason)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, store.ErrGameNotFound) {
+			status = http.StatusNotFound
+		}
+		writeError(w, status, err)
+		return
+	}
+	s.refreshDerivedResults(ctx, week.ID)
+	s.webhooks.Fire(models.WebhookEventGameResult, map[string]any{
+		"seasonId":   seasonID,
+		"weekNumber": weekNumber,
+		"game":       game,
+	})
+
+	writeJSON(w, http.StatusOK, map[string]any{"game": game})
+}
+
+func (s *Server) handleUnlockGame(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	seasonID, weekNumber, err := parseSeasonWeekParams(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	gameKey := chi.URLParam(r, "gameKey")
+	if strings.TrimSpace(gameKey) == "" {
+		writeError(w, http.StatusBadRequest, errors.New("gameKey is required"))
+		return
+	}
+
+	week, err := s.store.GetWeek(ctx, seasonID, weekNumber)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, store.ErrSeasonNotFound) || errors.Is(err, store.ErrWeekNotFound) {
+			status = http.StatusNotFound
+		}
+		writeError(w, status, err)
+		return
+	}
+
+	game, err := s.store.UnlockGame(ctx, week.ID, gameKey)
 	if err != nil {
 		status := http.StatusInternalServerError
 		if errors.Is(err, store.ErrGameNotFound) {
@@ -360,6 +531,12 @@ func (s *Server) handleSetGameWinner(w http.ResponseWriter, r *http.Request) {
 		writeError(w, status, err)
 		return
 	}
+	s.refreshDerivedResults(ctx, week.ID)
+	s.webhooks.Fire(models.WebhookEventGameResult, map[string]any{
+		"seasonId":   seasonID,
+		"weekNumber": weekNumber,
+		"game":       game,
+	})
 
 	writeJSON(w, http.StatusOK, map[string]any{"game": game})
 }
@@ -402,6 +579,12 @@ func (s *Server) handleDeclareWinner(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusInternalServerError, err)
 		return
 	}
+	s.refreshDerivedResults(ctx, week.ID)
+	s.webhooks.Fire(models.WebhookEventWeekWinner, map[string]any{
+		"seasonId":   seasonID,
+		"weekNumber": weekNumber,
+		"weekResult": result,
+	})
 
 	writeJSON(w, http.StatusOK, map[string]any{"weekResult": result})
 }
@@ -434,7 +617,16 @@ func (s *Server) handleSyncWeek(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	snapshots, err := s.syncWeek(ctx, season, week)
+	var req syncRequest
+	if err := decodeJSON(r, &req); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	snapshots, report, err := s.syncWeek(ctx, season, week, store.SyncOptions{
+		OverrideManual: req.OverrideManual,
+		DryRun:         req.DryRun,
+	})
 	if err != nil {
 		status := http.StatusInternalServerError
 		switch {
@@ -449,36 +641,62 @@ func (s *Server) handleSyncWeek(w http.ResponseWriter, r *http.Request) {
 
 	writeJSON(w, http.StatusOK, map[string]any{
 		"syncedGames": len(snapshots),
+		"dryRun":      report.DryRun,
+		"games":       report.Games,
+		"unchanged":   report.Unchanged,
+		"conflicts":   report.Conflicts,
 	})
 }
 
-func (s *Server) syncWeek(ctx context.Context, season *models.Season, week *models.Week) ([]sportsdata.GameSnapshot, error) {
+func (s *Server) syncWeek(ctx context.Context, season *models.Season, week *models.Week, opts store.SyncOptions) ([]sportsdata.GameSnapshot, *models.SyncReport, error) {
 	if season == nil || week == nil {
-		return nil, fmt.Errorf("sync: season and week must be provided")
+		return nil, nil, fmt.Errorf("sync: season and week must be provided")
 	}
 
 	if strings.TrimSpace(s.cfg.SportsAPIKey) == "" {
-		return nil, errSportsAPIKeyMissing
+		return nil, nil, errSportsAPIKeyMissing
 	}
 
 	if strings.TrimSpace(season.SportsDataSeasonKey) == "" {
-		return nil, fmt.Errorf("sync: season %s is missing a sports data key", season.ID)
+		return nil, nil, fmt.Errorf("sync: season %s is missing a sports data key", season.ID)
 	}
 
 	if week.Number <= 0 {
-		return nil, fmt.Errorf("sync: invalid week number %d", week.Number)
+		return nil, nil, fmt.Errorf("sync: invalid week number %d", week.Number)
 	}
 
 	snapshots, err := sportsdata.FetchScoresByWeek(ctx, nil, s.cfg.SportsAPIBaseURL, s.cfg.SportsAPIKey, season.SportsDataSeasonKey, week.Number)
 	if err != nil {
-		return nil, fmt.Errorf("%w: %v", errSportsDataUnavailable, err)
+		return nil, nil, fmt.Errorf("%w: %v", errSportsDataUnavailable, err)
 	}
 
-	if err := s.store.SyncWeekFromSnapshots(ctx, *season, *week, snapshots); err != nil {
-		return nil, err
+	report, err := s.store.SyncWeekFromSnapshots(ctx, *season, *week, snapshots, opts)
+	if err != nil {
+		return nil, nil, err
 	}
+	if !report.DryRun {
+		s.refreshDerivedResults(ctx, week.ID)
+		s.webhooks.Fire(models.WebhookEventWeekSynced, map[string]any{
+			"seasonId":   season.ID,
+			"weekNumber": week.Number,
+			"games":      report.Games,
+			"unchanged":  report.Unchanged,
+			"conflicts":  report.Conflicts,
+		})
+	}
+
+	return snapshots, report, nil
+}
 
-	return snapshots, nil
+// refreshDerivedResults recomputes the week's highlights and queues a
+// rebuild of the rating history after its results change. The change itself
+// has already been saved, so failures are only logged.
+func (s *Server) refreshDerivedResults(ctx context.Context, seasonWeekID string) {
+	if err := s.store.RefreshGameTags(ctx, seasonWeekID); err != nil {
+		log.Printf("http: refresh game tags for week %s: %v", seasonWeekID, err)
+	}
+	s.ratings.Request()
+	s.probabilities.reset()
 }
 
 func writeJSON(w http.ResponseWriter, status int, payload any) {
@@ -520,9 +738,10 @@ func decodeJSON(r *http.Request, dst any) error {
 }
 
 type pickRequest struct {
-	MemberID string `json:"memberId"`
-	GameKey  string `json:"gameKey"`
-	Side     string `json:"side"`
+	MemberID  string `json:"memberId"`
+	GameKey   string `json:"gameKey"`
+	Side      string `json:"side"`
+	EnteredBy string `json:"enteredBy,omitempty"`
 }
 
 type tieBreakerRequest struct {
@@ -532,15 +751,28 @@ type tieBreakerRequest struct {
 
 type setGameWinnerRequest struct {
 	Winner *string `json:"winner"`
+	Reason string  `json:"reason,omitempty"`
+}
+
+type syncRequest struct {
+	OverrideManual bool `json:"overrideManual,omitempty"`
+	DryRun         bool `json:"dryRun,omitempty"`
 }
 
 type declareWinnerRequest struct {
 	WinnerMemberID     string `json:"winnerMemberId"`
 	DeclaredByMemberID string `json:"declaredByMemberId"`
-	Notes              string `json:"notes"`
+	Notes              string `json:"notes,omitempty"`
+}
+
+type seasonSettingsRequest struct {
+	TieScoring    *string `json:"tieScoring"`
+	StandingsRule *string `json:"standingsRule"`
+	AutoPickRule  *string `json:"autoPickRule"`
 }
 
 type deletePickRequest struct {
-	MemberID string `json:"memberId"`
-	GameKey  string `json:"gameKey"`
+	MemberID  string `json:"memberId,omitempty"`
+	GameKey   string `json:"gameKey,omitempty"`
+	EnteredBy string `json:"enteredBy,omitempty"`
 }
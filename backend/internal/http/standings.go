@@ -0,0 +1,46 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"pickem/backend/internal/store"
+)
+
+func (s *Server) handleGetStandings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	seasonID := chi.URLParam(r, "seasonID")
+	if strings.TrimSpace(seasonID) == "" {
+		writeError(w, http.StatusBadRequest, errors.New("seasonID is required"))
+		return
+	}
+
+	throughWeek := 0
+	if raw := strings.TrimSpace(r.URL.Query().Get("week")); raw != "" {
+		week, err := strconv.Atoi(raw)
+		if err != nil || week <= 0 {
+			writeError(w, http.StatusBadRequest, errors.New("week must be a positive integer"))
+			return
+		}
+		throughWeek = week
+	}
+
+	standings, err := s.store.GetStandings(ctx, seasonID, r.URL.Query().Get("rule"), throughWeek)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, store.ErrSeasonNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, store.ErrInvalidStandingsRule):
+			status = http.StatusBadRequest
+		}
+		writeError(w, status, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"standings": standings})
+}
@@ -0,0 +1,33 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"pickem/backend/internal/store"
+)
+
+func (s *Server) handleGetMemberStats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	seasonID := strings.TrimSpace(chi.URLParam(r, "seasonID"))
+	memberID := strings.TrimSpace(chi.URLParam(r, "memberID"))
+	if seasonID == "" || memberID == "" {
+		writeError(w, http.StatusBadRequest, errors.New("seasonID and memberID are required"))
+		return
+	}
+
+	stats, err := s.store.GetMemberStats(ctx, seasonID, memberID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, store.ErrSeasonNotFound) || errors.Is(err, store.ErrMemberNotFound) {
+			status = http.StatusNotFound
+		}
+		writeError(w, status, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"stats": stats})
+}
@@ -0,0 +1,131 @@
+package httpapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"pickem/backend/internal/store"
+)
+
+const defaultDeliveryLimit = 50
+
+type createWebhookRequest struct {
+	MemberID string   `json:"memberId"`
+	URL      string   `json:"url"`
+	Secret   string   `json:"secret,omitempty"`
+	Events   []string `json:"events" enum:"pick.saved,pick.deleted,game.result,week.synced,week.winner"`
+}
+
+// requireCommissioner writes the error response and returns false unless
+// memberID belongs to a commissioner.
+func (s *Server) requireCommissioner(ctx context.Context, w http.ResponseWriter, memberID string) bool {
+	memberID = strings.TrimSpace(memberID)
+	if memberID == "" {
+		writeError(w, http.StatusBadRequest, errors.New("memberId is required"))
+		return false
+	}
+	if err := s.store.RequireCommissioner(ctx, memberID); err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, store.ErrMemberNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, store.ErrNotCommissioner):
+			status = http.StatusForbidden
+		}
+		writeError(w, status, err)
+		return false
+	}
+	return true
+}
+
+func (s *Server) handleListWebhooks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if !s.requireCommissioner(ctx, w, r.URL.Query().Get("memberId")) {
+		return
+	}
+
+	hooks, err := s.store.ListWebhooks(ctx)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"webhooks": hooks})
+}
+
+func (s *Server) handleCreateWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req createWebhookRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if !s.requireCommissioner(ctx, w, req.MemberID) {
+		return
+	}
+
+	hook, err := s.store.CreateWebhook(ctx, req.URL, req.Secret, req.Events, strings.TrimSpace(req.MemberID))
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, store.ErrInvalidWebhook) {
+			status = http.StatusBadRequest
+		}
+		writeError(w, status, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, map[string]any{"webhook": hook})
+}
+
+func (s *Server) handleDeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if !s.requireCommissioner(ctx, w, r.URL.Query().Get("memberId")) {
+		return
+	}
+
+	if err := s.store.DeleteWebhook(ctx, chi.URLParam(r, "webhookID")); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, store.ErrWebhookNotFound) {
+			status = http.StatusNotFound
+		}
+		writeError(w, status, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"removed": true})
+}
+
+func (s *Server) handleListWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if !s.requireCommissioner(ctx, w, r.URL.Query().Get("memberId")) {
+		return
+	}
+
+	limit := defaultDeliveryLimit
+	if raw := strings.TrimSpace(r.URL.Query().Get("limit")); raw != "" {
+		value, err := strconv.Atoi(raw)
+		if err != nil || value <= 0 || value > 500 {
+			writeError(w, http.StatusBadRequest, errors.New("limit must be between 1 and 500"))
+			return
+		}
+		limit = value
+	}
+
+	deliveries, err := s.store.ListWebhookDeliveries(ctx, chi.URLParam(r, "webhookID"), limit)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, store.ErrWebhookNotFound) {
+			status = http.StatusNotFound
+		}
+		writeError(w, status, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"deliveries": deliveries})
+}
@@ -2,6 +2,67 @@ package models
 
 import "time"
 
+// Game statuses stored in games.status. Final and forfeit games are graded;
+// canceled games void every pick made on them.
+const (
+	GameStatusScheduled  = "scheduled"
+	GameStatusInProgress = "in-progress"
+	GameStatusFinal      = "final"
+	GameStatusPostponed  = "postponed"
+	GameStatusSuspended  = "suspended"
+	GameStatusCanceled   = "canceled"
+	GameStatusForfeit    = "forfeit"
+)
+
+// Pick statuses reported on GamePick.
+const (
+	PickStatusPending   = "pending"
+	PickStatusCorrect   = "correct"
+	PickStatusIncorrect = "incorrect"
+	PickStatusVoid      = "void"
+	PickStatusPush      = "push"
+)
+
+// Pick history actions stored in pick_history.action.
+const (
+	PickActionSaved   = "saved"
+	PickActionDeleted = "deleted"
+	PickActionAuto    = "auto"
+)
+
+// GameWinnerTie is stored in games.winner when a graded game ends level.
+const GameWinnerTie = "tie"
+
+// Game tags stored in game_tags. Each week has at most one game per tag.
+const (
+	GameTagBiggestUpset = "biggest-upset"
+	GameTagClosestGame  = "closest-game"
+	GameTagDecidedWeek  = "decided-week"
+)
+
+// Webhook events. Subscriptions list the events they want delivered.
+const (
+	WebhookEventPickSaved   = "pick.saved"
+	WebhookEventPickDeleted = "pick.deleted"
+	WebhookEventGameResult  = "game.result"
+	WebhookEventWeekSynced  = "week.synced"
+	WebhookEventWeekWinner  = "week.winner"
+)
+
+// Result sources for games.result_source. Manual results are commissioner
+// overrides that provider syncs leave alone unless told otherwise.
+const (
+	ResultSourceProvider = "provider"
+	ResultSourceManual   = "manual"
+)
+
+// Tie scoring modes for season_settings.tie_scoring.
+const (
+	TieScoringZero = "zero"
+	TieScoringHalf = "half"
+	TieScoringWin  = "win"
+)
+
 type Season struct {
 	ID                  string `json:"id"`
 	Label               string `json:"label"`
@@ -18,8 +79,18 @@ type Week struct {
 }
 
 type RecordSummary struct {
-	Wins   int `json:"wins"`
-	Losses int `json:"losses"`
+	Wins   int     `json:"wins"`
+	Losses int     `json:"losses"`
+	Ties   int     `json:"ties"`
+	Points float64 `json:"points"`
+}
+
+type SeasonSettings struct {
+	SeasonID      string `json:"seasonId"`
+	CurrentWeek   int    `json:"currentWeek"`
+	TieScoring    string `json:"tieScoring"`
+	StandingsRule string `json:"standingsRule"`
+	AutoPickRule  string `json:"autoPickRule"`
 }
 
 type Member struct {
@@ -30,6 +101,10 @@ type Member struct {
 	LastWeekRecord RecordSummary `json:"lastWeekRecord"`
 	WeeksWon       int           `json:"weeksWon"`
 	TieBreakers    map[int]int   `json:"tieBreakers"`
+
+	WeekWinProbability   *float64 `json:"weekWinProbability,omitempty"`
+	SeasonWinProbability *float64 `json:"seasonWinProbability,omitempty"`
+	Rating               *float64 `json:"rating,omitempty"`
 }
 
 type TeamInfo struct {
@@ -42,21 +117,43 @@ type GamePick struct {
 	MemberID   string `json:"memberId"`
 	ChosenSide string `json:"chosenSide"`
 	Status     string `json:"status"`
+	Auto       bool   `json:"auto,omitempty"`
+	EnteredBy  string `json:"enteredBy,omitempty"`
 }
 
 type Game struct {
-	ID        string     `json:"id"`
-	GameKey   string     `json:"gameKey"`
-	Kickoff   *time.Time `json:"kickoff,omitempty"`
-	Location  string     `json:"location"`
-	Status    string     `json:"status"`
-	Channel   string     `json:"channel,omitempty"`
-	HomeTeam  TeamInfo   `json:"homeTeam"`
-	AwayTeam  TeamInfo   `json:"awayTeam"`
-	HomeScore *int       `json:"homeScore,omitempty"`
-	AwayScore *int       `json:"awayScore,omitempty"`
-	Winner    string     `json:"winner,omitempty"`
-	Picks     []GamePick `json:"picks"`
+	ID              string     `json:"id"`
+	GameKey         string     `json:"gameKey"`
+	Kickoff         *time.Time `json:"kickoff,omitempty"`
+	Location        string     `json:"location"`
+	Status          string     `json:"status"`
+	Channel         string     `json:"channel,omitempty"`
+	HomeTeam        TeamInfo   `json:"homeTeam"`
+	AwayTeam        TeamInfo   `json:"awayTeam"`
+	HomeScore       *int       `json:"homeScore,omitempty"`
+	AwayScore       *int       `json:"awayScore,omitempty"`
+	Winner          string     `json:"winner,omitempty"`
+	MovedFromWeek   *int       `json:"movedFromWeek,omitempty"`
+	ResultSource    string     `json:"resultSource,omitempty" enum:"provider,manual"`
+	ResultReason    string     `json:"resultReason,omitempty"`
+	ResultUpdatedAt *time.Time `json:"resultUpdatedAt,omitempty"`
+	PointSpread     *float64   `json:"pointSpread,omitempty"`
+	OverUnder       *float64   `json:"overUnder,omitempty"`
+	HomeMoneyLine   *int       `json:"homeMoneyLine,omitempty"`
+	AwayMoneyLine   *int       `json:"awayMoneyLine,omitempty"`
+	Picks           []GamePick `json:"picks"`
+	Consensus       *Consensus `json:"consensus,omitempty"`
+	Tags            []string   `json:"tags,omitempty" enum:"biggest-upset,closest-game,decided-week"`
+}
+
+type Consensus struct {
+	HomePicks  int      `json:"homePicks"`
+	AwayPicks  int      `json:"awayPicks"`
+	HomePct    float64  `json:"homePct"`
+	AwayPct    float64  `json:"awayPct"`
+	Side       string   `json:"side,omitempty" enum:"home,away"`
+	Unanimous  bool     `json:"unanimous"`
+	LoneWolves []string `json:"loneWolves"`
 }
 
 type WeekResult struct {
@@ -67,6 +164,364 @@ type WeekResult struct {
 	DeclaredAt         *time.Time `json:"declaredAt,omitempty"`
 }
 
+type SyncConflict struct {
+	GameKey        string `json:"gameKey"`
+	ManualWinner   string `json:"manualWinner"`
+	ProviderWinner string `json:"providerWinner"`
+	ProviderStatus string `json:"providerStatus"`
+	HomeScore      *int   `json:"homeScore,omitempty"`
+	AwayScore      *int   `json:"awayScore,omitempty"`
+	Overridden     bool   `json:"overridden"`
+}
+
+// Change kinds reported in SyncGameDiff.Changes.
+const (
+	SyncChangeNew     = "new"
+	SyncChangeWeek    = "week"
+	SyncChangeKickoff = "kickoff"
+	SyncChangeScore   = "score"
+	SyncChangeStatus  = "status"
+	SyncChangeWinner  = "winner"
+	SyncChangeRegrade = "picks-regraded"
+)
+
+type SyncGameState struct {
+	WeekNumber int        `json:"weekNumber"`
+	Kickoff    *time.Time `json:"kickoff,omitempty"`
+	Status     string     `json:"status"`
+	HomeScore  *int       `json:"homeScore,omitempty"`
+	AwayScore  *int       `json:"awayScore,omitempty"`
+	Winner     string     `json:"winner,omitempty"`
+}
+
+type SyncGameDiff struct {
+	GameKey       string         `json:"gameKey"`
+	HomeTeam      string         `json:"homeTeam"`
+	AwayTeam      string         `json:"awayTeam"`
+	Changes       []string       `json:"changes" enum:"new,week,kickoff,score,status,winner,picks-regraded"`
+	Before        *SyncGameState `json:"before,omitempty"`
+	After         SyncGameState  `json:"after"`
+	RegradedPicks int            `json:"regradedPicks"`
+}
+
+type SyncReport struct {
+	DryRun    bool           `json:"dryRun"`
+	Games     []SyncGameDiff `json:"games"`
+	Unchanged int            `json:"unchanged"`
+	Conflicts []SyncConflict `json:"conflicts"`
+}
+
+type WeeklyRecord struct {
+	WeekNumber int           `json:"weekNumber"`
+	Record     RecordSummary `json:"record"`
+	WonWeek    bool          `json:"wonWeek"`
+}
+
+type StandingsEntry struct {
+	Rank         int            `json:"rank"`
+	Tied         bool           `json:"tied"`
+	MemberID     string         `json:"memberId"`
+	Name         string         `json:"name"`
+	WeeksWon     int            `json:"weeksWon"`
+	Record       RecordSummary  `json:"record"`
+	WinPct       float64        `json:"winPct"`
+	GamesBehind  float64        `json:"gamesBehind"`
+	PreviousRank *int           `json:"previousRank,omitempty"`
+	Movement     int            `json:"movement"`
+	Weeks        []WeeklyRecord `json:"weeks"`
+}
+
+type Standings struct {
+	SeasonID    string           `json:"seasonId"`
+	Rule        string           `json:"rule" enum:"weeks-won,total-correct,win-pct"`
+	ThroughWeek int              `json:"throughWeek"`
+	Weeks       []int            `json:"weeks"`
+	Entries     []StandingsEntry `json:"entries"`
+}
+
+type WeekContender struct {
+	MemberID      string  `json:"memberId"`
+	CurrentPoints float64 `json:"currentPoints"`
+	MaxPoints     float64 `json:"maxPoints"`
+	CanWin        bool    `json:"canWin"`
+}
+
+type SeasonContender struct {
+	MemberID    string  `json:"memberId"`
+	Current     float64 `json:"current"`
+	BestCase    float64 `json:"bestCase"`
+	CanWinTitle bool    `json:"canWinTitle"`
+}
+
+type ContenderReport struct {
+	SeasonID       string            `json:"seasonId"`
+	WeekNumber     int               `json:"weekNumber"`
+	AllowTies      bool              `json:"allowTies"`
+	RemainingGames int               `json:"remainingGames"`
+	Week           []WeekContender   `json:"week"`
+	SeasonRule     string            `json:"seasonRule" enum:"weeks-won,total-correct,win-pct"`
+	RemainingWeeks int               `json:"remainingWeeks"`
+	Season         []SeasonContender `json:"season"`
+}
+
+type MemberProbability struct {
+	MemberID string  `json:"memberId"`
+	Week     float64 `json:"week"`
+	Season   float64 `json:"season"`
+}
+
+type ProbabilityReport struct {
+	SeasonID   string              `json:"seasonId"`
+	WeekNumber int                 `json:"weekNumber"`
+	Iterations int                 `json:"iterations"`
+	SeasonRule string              `json:"seasonRule" enum:"weeks-won,total-correct,win-pct"`
+	Members    []MemberProbability `json:"members"`
+}
+
+type StatLine struct {
+	Picks    int           `json:"picks"`
+	Record   RecordSummary `json:"record"`
+	Accuracy float64       `json:"accuracy"`
+}
+
+type TeamStats struct {
+	Team    string   `json:"team"`
+	Games   StatLine `json:"games"`
+	Backed  StatLine `json:"backed"`
+	Against StatLine `json:"against"`
+}
+
+type StreakStats struct {
+	LongestCorrect   int `json:"longestCorrect"`
+	LongestIncorrect int `json:"longestIncorrect"`
+	Current          int `json:"current"`
+}
+
+type ConsensusStats struct {
+	Games  int     `json:"games"`
+	Agreed int     `json:"agreed"`
+	Rate   float64 `json:"rate"`
+}
+
+type MemberStats struct {
+	SeasonID   string         `json:"seasonId"`
+	MemberID   string         `json:"memberId"`
+	Name       string         `json:"name"`
+	Overall    StatLine       `json:"overall"`
+	Home       StatLine       `json:"home"`
+	Away       StatLine       `json:"away"`
+	Favorite   StatLine       `json:"favorite"`
+	Underdog   StatLine       `json:"underdog"`
+	Teams      []TeamStats    `json:"teams"`
+	Streaks    StreakStats    `json:"streaks"`
+	BestWeek   *WeeklyRecord  `json:"bestWeek,omitempty"`
+	WorstWeek  *WeeklyRecord  `json:"worstWeek,omitempty"`
+	Consensus  ConsensusStats `json:"consensus"`
+	Contrarian StatLine       `json:"contrarian"`
+}
+
+type HeadToHeadSide struct {
+	MemberID         string        `json:"memberId"`
+	Name             string        `json:"name"`
+	Record           RecordSummary `json:"record"`
+	WeeksAhead       int           `json:"weeksAhead"`
+	WeeksWon         int           `json:"weeksWon"`
+	DisagreementsWon int           `json:"disagreementsWon"`
+}
+
+type HeadToHeadWeek struct {
+	SeasonID       string  `json:"seasonId"`
+	WeekNumber     int     `json:"weekNumber"`
+	MemberPoints   float64 `json:"memberPoints"`
+	OpponentPoints float64 `json:"opponentPoints"`
+	LeaderID       string  `json:"leaderId,omitempty"`
+}
+
+type HeadToHeadGame struct {
+	SeasonID     string `json:"seasonId"`
+	WeekNumber   int    `json:"weekNumber"`
+	GameKey      string `json:"gameKey"`
+	HomeTeam     string `json:"homeTeam"`
+	AwayTeam     string `json:"awayTeam"`
+	MemberSide   string `json:"memberSide" enum:"home,away"`
+	OpponentSide string `json:"opponentSide" enum:"home,away"`
+	Winner       string `json:"winner,omitempty"`
+	WinnerID     string `json:"winnerId,omitempty"`
+}
+
+type HeadToHead struct {
+	SeasonID      string           `json:"seasonId,omitempty"`
+	Member        HeadToHeadSide   `json:"member"`
+	Opponent      HeadToHeadSide   `json:"opponent"`
+	WeeksLevel    int              `json:"weeksLevel"`
+	Weeks         []HeadToHeadWeek `json:"weeks"`
+	Disagreements []HeadToHeadGame `json:"disagreements"`
+}
+
+type HallOfFameWeek struct {
+	SeasonID    string        `json:"seasonId"`
+	SeasonLabel string        `json:"seasonLabel"`
+	WeekNumber  int           `json:"weekNumber"`
+	Record      RecordSummary `json:"record"`
+}
+
+type HallOfFameEntry struct {
+	MemberID     string          `json:"memberId"`
+	Name         string          `json:"name"`
+	Seasons      int             `json:"seasons"`
+	Titles       int             `json:"titles"`
+	TitleSeasons []string        `json:"titleSeasons"`
+	WeeksWon     int             `json:"weeksWon"`
+	Record       RecordSummary   `json:"record"`
+	WinPct       float64         `json:"winPct"`
+	BestWeek     *HallOfFameWeek `json:"bestWeek,omitempty"`
+	Streaks      StreakStats     `json:"streaks"`
+}
+
+type RecordHolder struct {
+	MemberID    string `json:"memberId"`
+	Name        string `json:"name"`
+	SeasonID    string `json:"seasonId,omitempty"`
+	SeasonLabel string `json:"seasonLabel,omitempty"`
+	WeekNumber  int    `json:"weekNumber,omitempty"`
+}
+
+type HallOfFameRecord struct {
+	Category string         `json:"category" enum:"most-titles,most-weeks-won,most-correct,best-week,longest-streak"`
+	Value    float64        `json:"value"`
+	Holders  []RecordHolder `json:"holders"`
+}
+
+type HallOfFame struct {
+	Seasons int                `json:"seasons"`
+	Members []HallOfFameEntry  `json:"members"`
+	Records []HallOfFameRecord `json:"records"`
+}
+
+type WeekSummary struct {
+	Games           int           `json:"games"`
+	Picks           int           `json:"picks"`
+	UnanimousGames  int           `json:"unanimousGames"`
+	SplitGames      int           `json:"splitGames"`
+	ConsensusRecord RecordSummary `json:"consensusRecord"`
+	LoneWolfPicks   int           `json:"loneWolfPicks"`
+	LoneWolfCorrect int           `json:"loneWolfCorrect"`
+}
+
+type RecapMember struct {
+	MemberID   string        `json:"memberId"`
+	Name       string        `json:"name"`
+	Record     RecordSummary `json:"record"`
+	TieBreaker *int          `json:"tieBreaker,omitempty"`
+}
+
+type RecapTieBreaker struct {
+	GameKey     string `json:"gameKey"`
+	HomeTeam    string `json:"homeTeam"`
+	AwayTeam    string `json:"awayTeam"`
+	ActualTotal *int   `json:"actualTotal,omitempty"`
+	Decided     bool   `json:"decided"`
+	WinnerGuess *int   `json:"winnerGuess,omitempty"`
+	Margin      *int   `json:"margin,omitempty"`
+}
+
+type RecapPick struct {
+	MemberID string `json:"memberId"`
+	Name     string `json:"name"`
+	GameKey  string `json:"gameKey"`
+	Team     string `json:"team"`
+	Opponent string `json:"opponent"`
+	Correct  bool   `json:"correct"`
+	Shared   int    `json:"shared"`
+	Picks    int    `json:"picks"`
+}
+
+type RecapUpset struct {
+	GameKey   string `json:"gameKey"`
+	Winner    string `json:"winner"`
+	Loser     string `json:"loser"`
+	HomeScore *int   `json:"homeScore,omitempty"`
+	AwayScore *int   `json:"awayScore,omitempty"`
+	ByLine    bool   `json:"byLine"`
+	Fooled    int    `json:"fooled"`
+	Picks     int    `json:"picks"`
+}
+
+type RecapHighlight struct {
+	Tag       string `json:"tag"`
+	GameKey   string `json:"gameKey"`
+	HomeTeam  string `json:"homeTeam"`
+	AwayTeam  string `json:"awayTeam"`
+	HomeScore *int   `json:"homeScore,omitempty"`
+	AwayScore *int   `json:"awayScore,omitempty"`
+}
+
+type WeekRecap struct {
+	SeasonID    string           `json:"seasonId"`
+	SeasonLabel string           `json:"seasonLabel"`
+	WeekNumber  int              `json:"weekNumber"`
+	WeekLabel   string           `json:"weekLabel"`
+	Declared    bool             `json:"declared"`
+	Winner      *RecapMember     `json:"winner,omitempty"`
+	Notes       string           `json:"notes,omitempty"`
+	TieBreaker  *RecapTieBreaker `json:"tieBreaker,omitempty"`
+	Leaderboard []RecapMember    `json:"leaderboard"`
+	BestPicks   []RecapPick      `json:"bestPicks"`
+	WorstPicks  []RecapPick      `json:"worstPicks"`
+	Upsets      []RecapUpset     `json:"upsets"`
+	Highlights  []RecapHighlight `json:"highlights"`
+	Standings   []StandingsEntry `json:"standings"`
+}
+
+type RatingPoint struct {
+	WeekNumber int     `json:"weekNumber"`
+	Rating     float64 `json:"rating"`
+	Change     float64 `json:"change"`
+	Games      int     `json:"games"`
+}
+
+type MemberRating struct {
+	MemberID    string        `json:"memberId"`
+	Name        string        `json:"name"`
+	StartRating float64       `json:"startRating"`
+	Rating      float64       `json:"rating"`
+	History     []RatingPoint `json:"history"`
+}
+
+type RatingReport struct {
+	SeasonID string         `json:"seasonId"`
+	Members  []MemberRating `json:"members"`
+}
+
+type MissingPicks struct {
+	MemberID  string `json:"memberId"`
+	Name      string `json:"name"`
+	Missing   int    `json:"missing"`
+	OpenGames int    `json:"openGames"`
+}
+
+type Webhook struct {
+	ID                string    `json:"id"`
+	URL               string    `json:"url"`
+	Events            []string  `json:"events" enum:"pick.saved,pick.deleted,game.result,week.synced,week.winner"`
+	Active            bool      `json:"active"`
+	CreatedByMemberID string    `json:"createdByMemberId,omitempty"`
+	CreatedAt         time.Time `json:"createdAt"`
+	Secret            string    `json:"secret,omitempty"`
+}
+
+type WebhookDelivery struct {
+	ID         string    `json:"id"`
+	WebhookID  string    `json:"webhookId"`
+	DeliveryID string    `json:"deliveryId"`
+	Event      string    `json:"event"`
+	Attempt    int       `json:"attempt"`
+	StatusCode *int      `json:"statusCode,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	Delivered  bool      `json:"delivered"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
 type PageData struct {
 	Season     Season      `json:"season"`
 	Weeks      []Week      `json:"weeks"`
@@ -74,4 +529,67 @@ type PageData struct {
 	Members    []Member    `json:"members"`
 	Games      []Game      `json:"games"`
 	WeekResult *WeekResult `json:"weekResult,omitempty"`
+	Summary    WeekSummary `json:"summary"`
+}
+
+type PickHistoryEntry struct {
+	ID            string    `json:"id"`
+	MemberID      string    `json:"memberId"`
+	MemberName    string    `json:"memberName"`
+	GameKey       string    `json:"gameKey"`
+	Action        string    `json:"action" enum:"saved,deleted,auto"`
+	ChosenSide    string    `json:"chosenSide,omitempty" enum:"home,away"`
+	EnteredBy     string    `json:"enteredBy,omitempty"`
+	EnteredByName string    `json:"enteredByName,omitempty"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+type ParsedPick struct {
+	Text    string   `json:"text"`
+	GameKey string   `json:"gameKey"`
+	Side    string   `json:"side" enum:"home,away"`
+	Team    TeamInfo `json:"team"`
+}
+
+type PickParseIssue struct {
+	Text       string     `json:"text"`
+	Kind       string     `json:"kind" enum:"unknown,not-playing,ambiguous,conflict"`
+	Candidates []TeamInfo `json:"candidates,omitempty"`
+}
+
+type PickParseResult struct {
+	MemberID   string           `json:"memberId"`
+	Message    string           `json:"message"`
+	Picks      []ParsedPick     `json:"picks"`
+	Issues     []PickParseIssue `json:"issues"`
+	TieBreaker *int             `json:"tieBreaker,omitempty"`
+	Missing    []string         `json:"missing"`
+	Committed  bool             `json:"committed"`
+}
+
+type SeasonImport struct {
+	SeasonID       string `json:"seasonId"`
+	Label          string `json:"label"`
+	DryRun         bool   `json:"dryRun"`
+	Members        int    `json:"members"`
+	MembersCreated int    `json:"membersCreated"`
+	Weeks          int    `json:"weeks"`
+	Games          int    `json:"games"`
+	Picks          int    `json:"picks"`
+	TieBreakers    int    `json:"tieBreakers"`
+	WeekWinners    int    `json:"weekWinners"`
+	Champion       string `json:"champion,omitempty"`
+}
+
+type ArchiveRestore struct {
+	Members        int `json:"members"`
+	MembersCreated int `json:"membersCreated"`
+	Seasons        int `json:"seasons"`
+	SeasonsCreated int `json:"seasonsCreated"`
+	Weeks          int `json:"weeks"`
+	Games          int `json:"games"`
+	Picks          int `json:"picks"`
+	TieBreakers    int `json:"tieBreakers"`
+	WeekResults    int `json:"weekResults"`
+	Titles         int `json:"titles"`
 }
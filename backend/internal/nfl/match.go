@@ -0,0 +1,164 @@
+package nfl
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// aliases maps nicknames, old abbreviations and shorthand people actually
+// type to team codes. Some aliases are shared ("la", "ny") and match both
+// teams.
+var aliases = map[string][]string{
+	"niners":  {"SF"},
+	"sf49ers": {"SF"},
+	"sfo":     {"SF"},
+	"pats":    {"NE"},
+	"nwe":     {"NE"},
+	"bucs":    {"TB"},
+	"tam":     {"TB"},
+	"jags":    {"JAX"},
+	"jac":     {"JAX"},
+	"fins":    {"MIA"},
+	"phins":   {"MIA"},
+	"hawks":   {"SEA"},
+	"pack":    {"GB"},
+	"gnb":     {"GB"},
+	"boys":    {"DAL"},
+	"bolts":   {"LAC"},
+	"sd":      {"LAC"},
+	"gmen":    {"NYG"},
+	"birds":   {"PHI"},
+	"cards":   {"ARI"},
+	"commies": {"WAS"},
+	"wsh":     {"WAS"},
+	"kan":     {"KC"},
+	"nor":     {"NO"},
+	"oak":     {"LV"},
+	"vegas":   {"LV"},
+	"philly":  {"PHI"},
+	"frisco":  {"SF"},
+	"tampa":   {"TB"},
+	"la":      {"LAC", "LAR"},
+	"ny":      {"NYG", "NYJ"},
+}
+
+// Match returns the teams a free-text reference could mean, sorted by code.
+// Codes, nicknames, locations and aliases match exactly first; only when
+// nothing matches exactly are near misses (a typo or two, or a prefix of at
+// least three letters) considered. Ambiguous text such as "New York"
+// returns every candidate.
+func Match(text string) []Team {
+	if exact := MatchExact(text); len(exact) > 0 {
+		return exact
+	}
+
+	query := normalize(text)
+	if len(query) < 3 {
+		return nil
+	}
+
+	near := map[string]bool{}
+	for code, team := range teamsByCode {
+		for _, key := range teamKeys(team) {
+			if closeEnough(query, key) {
+				near[code] = true
+			}
+		}
+	}
+	for alias, codes := range aliases {
+		if closeEnough(query, alias) {
+			for _, code := range codes {
+				near[code] = true
+			}
+		}
+	}
+	return teamsFor(near)
+}
+
+// MatchExact is Match without the near misses.
+func MatchExact(text string) []Team {
+	query := normalize(text)
+	if query == "" {
+		return nil
+	}
+
+	exact := map[string]bool{}
+	for code, team := range teamsByCode {
+		for _, key := range teamKeys(team) {
+			if key == query {
+				exact[code] = true
+			}
+		}
+	}
+	for _, code := range aliases[query] {
+		exact[code] = true
+	}
+	return teamsFor(exact)
+}
+
+func teamKeys(team Team) []string {
+	return []string{
+		normalize(team.Code),
+		normalize(team.Name),
+		normalize(team.Location),
+		normalize(team.Location + team.Name),
+		normalize(team.Code + team.Name),
+	}
+}
+
+func teamsFor(codes map[string]bool) []Team {
+	teams := make([]Team, 0, len(codes))
+	for code := range codes {
+		teams = append(teams, teamsByCode[code])
+	}
+	sort.Slice(teams, func(i, j int) bool { return teams[i].Code < teams[j].Code })
+	return teams
+}
+
+// closeEnough allows one typo in short words and two in longer ones, and
+// treats a query of three or more letters as a prefix ("phil", "giant").
+// Codes are too short to fuzz without matching half the league.
+func closeEnough(query, key string) bool {
+	if len(query) < 3 || len(key) < 4 {
+		return false
+	}
+	if strings.HasPrefix(key, query) {
+		return true
+	}
+	limit := 1
+	if len(query) > 6 {
+		limit = 2
+	}
+	return editDistance(query, key) <= limit
+}
+
+func normalize(text string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(text) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
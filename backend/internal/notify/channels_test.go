@@ -0,0 +1,251 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testMessage = Message{
+	Kind:    "reminder",
+	Subject: "Week 3 picks — due Sunday",
+	Body:    "Two games still need a pick.\nGood luck!",
+	Recipients: []Recipient{
+		{MemberID: "m1", Name: "Alex", Email: "alex@example.com"},
+		{MemberID: "m2", Name: "Sam"},
+	},
+}
+
+func TestWebhookChannelPostsJSON(t *testing.T) {
+	var got Message
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %s, want POST", r.Method)
+		}
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Content-Type = %q", ct)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	if err := NewWebhookChannel(server.URL, nil).Send(context.Background(), testMessage); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if got.Subject != testMessage.Subject || got.Body != testMessage.Body || len(got.Recipients) != 2 {
+		t.Errorf("posted %+v", got)
+	}
+	if got.Recipients[0].Email != "" {
+		t.Errorf("email address leaked to the webhook: %q", got.Recipients[0].Email)
+	}
+}
+
+func TestWebhookChannelRejectsErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "nope", http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	err := NewWebhookChannel(server.URL, nil).Send(context.Background(), testMessage)
+	if err == nil || !strings.Contains(err.Error(), "502") {
+		t.Fatalf("Send error = %v, want a 502", err)
+	}
+}
+
+func TestNtfyChannelPublishesToTopic(t *testing.T) {
+	var path, title, body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		title = r.Header.Get("Title")
+		raw, _ := io.ReadAll(r.Body)
+		body = string(raw)
+	}))
+	defer server.Close()
+
+	if err := NewNtfyChannel(server.URL+"/", "/pickem", nil).Send(context.Background(), testMessage); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if path != "/pickem" {
+		t.Errorf("path = %q, want /pickem", path)
+	}
+	if title != testMessage.Subject {
+		t.Errorf("Title = %q, want %q", title, testMessage.Subject)
+	}
+	if body != testMessage.Body {
+		t.Errorf("body = %q, want %q", body, testMessage.Body)
+	}
+}
+
+func TestNtfyChannelRejectsErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "slow down", http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	if err := NewNtfyChannel(server.URL, "pickem", nil).Send(context.Background(), testMessage); err == nil {
+		t.Fatal("Send succeeded against a 429")
+	}
+}
+
+func TestSMTPChannelDeliversToEachAddress(t *testing.T) {
+	listener, mails := fakeSMTPServer(t)
+	host, port, _ := net.SplitHostPort(listener.Addr().String())
+
+	channel := NewSMTPChannel(host, port, "", "", "pickem@example.com")
+	if err := channel.Send(context.Background(), testMessage); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	select {
+	case mail := <-mails:
+		if mail.from != "pickem@example.com" {
+			t.Errorf("MAIL FROM = %q", mail.from)
+		}
+		if len(mail.to) != 1 || mail.to[0] != "alex@example.com" {
+			t.Errorf("RCPT TO = %v", mail.to)
+		}
+		if !strings.Contains(mail.data, "Subject: =?utf-8?q?Week_3_picks_=E2=80=94_due_Sunday?=\r\n") {
+			t.Errorf("subject is not Q-encoded:\n%s", mail.data)
+		}
+		if !strings.Contains(mail.data, "Hi Alex,\r\n\r\nTwo games still need a pick.\r\nGood luck!\r\n") {
+			t.Errorf("unexpected body:\n%s", mail.data)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no mail delivered")
+	}
+
+	select {
+	case mail := <-mails:
+		t.Errorf("unexpected second mail to %v; Sam has no address", mail.to)
+	default:
+	}
+}
+
+func TestSMTPChannelHonoursContext(t *testing.T) {
+	// A server that accepts and then never greets.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		io.Copy(io.Discard, conn)
+	}()
+	host, port, _ := net.SplitHostPort(listener.Addr().String())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- NewSMTPChannel(host, port, "", "", "pickem@example.com").Send(ctx, testMessage)
+	}()
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Fatal("Send succeeded against a silent server")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Send ignored the context deadline")
+	}
+}
+
+type fakeMail struct {
+	from string
+	to   []string
+	data string
+}
+
+// fakeSMTPServer speaks just enough SMTP to accept mail and reports each
+// message it receives.
+func fakeSMTPServer(t *testing.T) (net.Listener, <-chan fakeMail) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	mails := make(chan fakeMail, 10)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveSMTP(conn, mails)
+		}
+	}()
+	return listener, mails
+}
+
+func serveSMTP(conn net.Conn, mails chan<- fakeMail) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	reply := func(line string) { io.WriteString(conn, line+"\r\n") }
+
+	reply("220 fake ESMTP")
+	var mail fakeMail
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		verb := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+		switch verb {
+		case "EHLO", "HELO":
+			reply("250 fake")
+		case "MAIL":
+			mail = fakeMail{from: smtpPath(line)}
+			reply("250 ok")
+		case "RCPT":
+			mail.to = append(mail.to, smtpPath(line))
+			reply("250 ok")
+		case "DATA":
+			reply("354 go ahead")
+			var data strings.Builder
+			for {
+				dataLine, err := reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if dataLine == ".\r\n" {
+					break
+				}
+				data.WriteString(dataLine)
+			}
+			mail.data = data.String()
+			mails <- mail
+			reply("250 queued")
+		case "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("502 not implemented")
+		}
+	}
+}
+
+func smtpPath(line string) string {
+	start, end := strings.Index(line, "<"), strings.Index(line, ">")
+	if start < 0 || end < start {
+		return ""
+	}
+	return line[start+1 : end]
+}
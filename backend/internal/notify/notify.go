@@ -0,0 +1,82 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"pickem/backend/internal/config"
+)
+
+// Recipient is a member a message is about. Channels that reach people
+// directly (email) use the contact details; broadcast channels ignore them.
+type Recipient struct {
+	MemberID string `json:"memberId"`
+	Name     string `json:"name"`
+	Email    string `json:"-"`
+}
+
+// Message is a notification ready to send.
+type Message struct {
+	Kind       string      `json:"kind"`
+	Subject    string      `json:"subject"`
+	Body       string      `json:"body"`
+	Recipients []Recipient `json:"recipients"`
+}
+
+// Channel delivers messages somewhere: an inbox, a chat bot, a phone.
+type Channel interface {
+	Name() string
+	Send(ctx context.Context, msg Message) error
+}
+
+// Notifier fans a message out to every configured channel.
+type Notifier struct {
+	channels []Channel
+}
+
+func NewNotifier(channels ...Channel) *Notifier {
+	return &Notifier{channels: channels}
+}
+
+// FromConfig enables each channel whose settings are present.
+func FromConfig(cfg config.Config) *Notifier {
+	channels := []Channel{}
+	if strings.TrimSpace(cfg.SMTPHost) != "" && strings.TrimSpace(cfg.SMTPFrom) != "" {
+		channels = append(channels, NewSMTPChannel(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.SMTPFrom))
+	}
+	if strings.TrimSpace(cfg.NotifyWebhookURL) != "" {
+		channels = append(channels, NewWebhookChannel(cfg.NotifyWebhookURL, nil))
+	}
+	if strings.TrimSpace(cfg.NtfyTopic) != "" {
+		channels = append(channels, NewNtfyChannel(cfg.NtfyURL, cfg.NtfyTopic, nil))
+	}
+	return NewNotifier(channels...)
+}
+
+// Enabled reports whether any channel is configured.
+func (n *Notifier) Enabled() bool {
+	return n != nil && len(n.channels) > 0
+}
+
+// Send delivers the message on every channel. A failing channel does not
+// stop the others; Send only fails when no channel succeeded.
+func (n *Notifier) Send(ctx context.Context, msg Message) error {
+	if !n.Enabled() {
+		return nil
+	}
+
+	var errs []error
+	for _, channel := range n.channels {
+		if err := channel.Send(ctx, msg); err != nil {
+			log.Printf("notify: %s: %v", channel.Name(), err)
+			errs = append(errs, fmt.Errorf("%s: %w", channel.Name(), err))
+		}
+	}
+	if len(errs) == len(n.channels) {
+		return fmt.Errorf("notify: every channel failed: %w", errors.Join(errs...))
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// NtfyChannel publishes push notifications to an ntfy topic. Anything that
+// speaks the ntfy publish API (a plain-text POST to /<topic> with a Title
+// header) works, including a self-hosted server.
+type NtfyChannel struct {
+	url    string
+	client *http.Client
+}
+
+// NewNtfyChannel publishes to topic on the server at baseURL. A nil client
+// uses a default with a short timeout.
+func NewNtfyChannel(baseURL, topic string, client *http.Client) *NtfyChannel {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &NtfyChannel{
+		url:    strings.TrimRight(baseURL, "/") + "/" + strings.TrimLeft(topic, "/"),
+		client: client,
+	}
+}
+
+func (c *NtfyChannel) Name() string { return "ntfy" }
+
+func (c *NtfyChannel) Send(ctx context.Context, msg Message) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, strings.NewReader(msg.Body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Title", msg.Subject)
+	req.Header.Set("Tags", "football")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package notify
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// SMTPChannel emails each recipient that has an address. Messages with no
+// reachable recipients are skipped.
+type SMTPChannel struct {
+	addr     string
+	host     string
+	username string
+	password string
+	from     string
+}
+
+func NewSMTPChannel(host, port, username, password, from string) *SMTPChannel {
+	return &SMTPChannel{
+		addr:     net.JoinHostPort(host, port),
+		host:     host,
+		username: username,
+		password: password,
+		from:     from,
+	}
+}
+
+func (c *SMTPChannel) Name() string { return "smtp" }
+
+func (c *SMTPChannel) Send(ctx context.Context, msg Message) error {
+	var auth smtp.Auth
+	if c.username != "" {
+		auth = smtp.PlainAuth("", c.username, c.password, c.host)
+	}
+
+	for _, recipient := range msg.Recipients {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if recipient.Email == "" {
+			continue
+		}
+		if err := c.sendMail(ctx, auth, recipient.Email, c.compose(recipient, msg)); err != nil {
+			return fmt.Errorf("send to %s: %w", recipient.Name, err)
+		}
+	}
+	return nil
+}
+
+// sendMail is smtp.SendMail with the connection tied to ctx: the dial honours
+// it, its deadline bounds the whole conversation and cancelling it closes the
+// connection.
+func (c *SMTPChannel) sendMail(ctx context.Context, auth smtp.Auth, to string, body []byte) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", c.addr)
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	stop := context.AfterFunc(ctx, func() { _ = conn.Close() })
+	defer stop()
+
+	client, err := smtp.NewClient(conn, c.host)
+	if err != nil {
+		conn.Close()
+		return withContext(ctx, err)
+	}
+	defer client.Close()
+
+	if err := c.converse(client, auth, to, body); err != nil {
+		return withContext(ctx, err)
+	}
+	return nil
+}
+
+func (c *SMTPChannel) converse(client *smtp.Client, auth smtp.Auth, to string, body []byte) error {
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: c.host}); err != nil {
+			return err
+		}
+	}
+	if auth != nil {
+		if ok, _ := client.Extension("AUTH"); !ok {
+			return fmt.Errorf("server does not support AUTH")
+		}
+		if err := client.Auth(auth); err != nil {
+			return err
+		}
+	}
+	if err := client.Mail(c.from); err != nil {
+		return err
+	}
+	if err := client.Rcpt(to); err != nil {
+		return err
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(body); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// withContext reports the context's error when it is why the conversation
+// broke off, rather than the read or write error it caused.
+func withContext(ctx context.Context, err error) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+	return err
+}
+
+func (c *SMTPChannel) compose(recipient Recipient, msg Message) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", c.from)
+	fmt.Fprintf(&b, "To: %s\r\n", recipient.Email)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("\r\n")
+	fmt.Fprintf(&b, "Hi %s,\r\n\r\n", recipient.Name)
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+	b.WriteString("\r\n")
+	return []byte(b.String())
+}
@@ -0,0 +1,57 @@
+package odds
+
+import (
+	"math"
+
+	"pickem/backend/internal/models"
+)
+
+// spreadStdDev is the standard deviation of NFL final margins around the
+// closing spread, in points.
+const spreadStdDev = 13.45
+
+// HomeWinProbability estimates the chance the home team wins from the game's
+// betting lines. Moneylines are preferred (with the bookmaker's margin
+// removed), then the point spread. The second result is false when the game
+// has no lines and the estimate is a coin flip.
+func HomeWinProbability(game models.Game) (float64, bool) {
+	if game.HomeMoneyLine != nil && game.AwayMoneyLine != nil {
+		home := impliedProbability(*game.HomeMoneyLine)
+		away := impliedProbability(*game.AwayMoneyLine)
+		if home+away > 0 {
+			return home / (home + away), true
+		}
+	}
+	if game.PointSpread != nil {
+		// A spread of -3 means the home team is expected to win by three.
+		return normalCDF(-*game.PointSpread / spreadStdDev), true
+	}
+	return 0.5, false
+}
+
+// Favorite returns the side the lines favor ("home" or "away"), or "" when
+// there are no lines or the game is a pick'em.
+func Favorite(game models.Game) string {
+	p, ok := HomeWinProbability(game)
+	if !ok || p == 0.5 {
+		return ""
+	}
+	if p > 0.5 {
+		return "home"
+	}
+	return "away"
+}
+
+func impliedProbability(moneyLine int) float64 {
+	switch {
+	case moneyLine < 0:
+		return float64(-moneyLine) / float64(-moneyLine+100)
+	case moneyLine > 0:
+		return 100 / float64(moneyLine+100)
+	}
+	return 0
+}
+
+func normalCDF(x float64) float64 {
+	return 0.5 * (1 + math.Erf(x/math.Sqrt2))
+}
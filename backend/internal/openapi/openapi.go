@@ -0,0 +1,360 @@
+package openapi
+
+import (
+	"fmt"
+	"go/token"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Version is the OpenAPI version the documents are written against.
+const Version = "3.0.3"
+
+// Document is the subset of an OpenAPI document the API needs.
+type Document struct {
+	OpenAPI    string                          `json:"openapi"`
+	Info       Info                            `json:"info"`
+	Paths      map[string]map[string]Operation `json:"paths"`
+	Components Components                      `json:"components"`
+}
+
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+type Operation struct {
+	OperationID string              `json:"operationId"`
+	Summary     string              `json:"summary,omitempty"`
+	Tags        []string            `json:"tags,omitempty"`
+	Parameters  []Parameter         `json:"parameters,omitempty"`
+	RequestBody *RequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]Response `json:"responses"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Required    bool    `json:"required,omitempty"`
+	Description string  `json:"description,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                 `json:"required,omitempty"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+}
+
+// Field is one property of an inline object. Value is either a *Schema or
+// a sample value whose type is reflected.
+type Field struct {
+	Name  string
+	Value any
+}
+
+// Builder assembles a document. Named struct types become components the
+// first time they are reflected and are referenced by $ref after that.
+type Builder struct {
+	doc   Document
+	names map[reflect.Type]string
+}
+
+func NewBuilder(title, version string) *Builder {
+	b := &Builder{
+		doc: Document{
+			OpenAPI:    Version,
+			Info:       Info{Title: title, Version: version},
+			Paths:      map[string]map[string]Operation{},
+			Components: Components{Schemas: map[string]*Schema{}},
+		},
+		names: map[reflect.Type]string{},
+	}
+	b.doc.Components.Schemas["Error"] = &Schema{
+		Type:       "object",
+		Properties: map[string]*Schema{"error": {Type: "string"}},
+		Required:   []string{"error"},
+	}
+	return b
+}
+
+var pathParam = regexp.MustCompile(`\{([^}]+)\}`)
+
+// Add documents one route. Path parameters are filled in from the pattern
+// (names ending in Number are integers, the rest strings) and every
+// operation gains the shared error response as its default.
+func (b *Builder) Add(method, path string, op Operation) {
+	declared := map[string]bool{}
+	for _, param := range op.Parameters {
+		if param.In == "path" {
+			declared[param.Name] = true
+		}
+	}
+	var params []Parameter
+	for _, match := range pathParam.FindAllStringSubmatch(path, -1) {
+		name := match[1]
+		if declared[name] {
+			continue
+		}
+		schema := &Schema{Type: "string"}
+		if strings.HasSuffix(name, "Number") {
+			schema = &Schema{Type: "integer"}
+		}
+		params = append(params, Parameter{Name: name, In: "path", Required: true, Schema: schema})
+	}
+	op.Parameters = append(params, op.Parameters...)
+
+	if op.Responses == nil {
+		op.Responses = map[string]Response{}
+	}
+	if _, ok := op.Responses["default"]; !ok {
+		op.Responses["default"] = JSONResponse("Error", &Schema{Ref: "#/components/schemas/Error"})
+	}
+
+	if b.doc.Paths[path] == nil {
+		b.doc.Paths[path] = map[string]Operation{}
+	}
+	b.doc.Paths[path][strings.ToLower(method)] = op
+}
+
+// Document returns the assembled document.
+func (b *Builder) Document() *Document {
+	return &b.doc
+}
+
+// Object is an inline object schema whose fields are all required.
+func (b *Builder) Object(fields ...Field) *Schema {
+	out := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	for _, field := range fields {
+		schema, ok := field.Value.(*Schema)
+		if !ok {
+			schema = b.Schema(field.Value)
+		}
+		out.Properties[field.Name] = schema
+		out.Required = append(out.Required, field.Name)
+	}
+	return out
+}
+
+// Wrap is the usual response envelope: an object with a single key.
+func (b *Builder) Wrap(name string, v any) *Schema {
+	return b.Object(Field{Name: name, Value: v})
+}
+
+// Schema reflects the JSON shape of v's type, following encoding/json's
+// rules for tags, omitempty and embedded structs. Fields without omitempty
+// are required; pointers are nullable. An `enum:"a,b"` tag limits a string
+// field, or the items of a string slice, to those values.
+func (b *Builder) Schema(v any) *Schema {
+	return b.schemaOf(reflect.TypeOf(v))
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+func (b *Builder) schemaOf(t reflect.Type) *Schema {
+	if t == nil {
+		return &Schema{}
+	}
+	if t == timeType {
+		return &Schema{Type: "string", Format: "date-time"}
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		inner := b.schemaOf(t.Elem())
+		if inner.Ref != "" {
+			return &Schema{AllOf: []*Schema{inner}, Nullable: true}
+		}
+		inner.Nullable = true
+		return inner
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: b.schemaOf(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: b.schemaOf(t.Elem())}
+	case reflect.Struct:
+		// Anonymous and unexported types, such as handler request bodies,
+		// are written inline.
+		if !token.IsExported(t.Name()) {
+			return b.structSchema(t)
+		}
+		return &Schema{Ref: "#/components/schemas/" + b.component(t)}
+	default:
+		return &Schema{}
+	}
+}
+
+// component registers a named struct once, under its type name prefixed
+// with its package name outside models (archive.Week is ArchiveWeek).
+func (b *Builder) component(t reflect.Type) string {
+	if name, ok := b.names[t]; ok {
+		return name
+	}
+	name := t.Name()
+	pkg := t.PkgPath()[strings.LastIndex(t.PkgPath(), "/")+1:]
+	if prefix := strings.ToUpper(pkg[:1]) + pkg[1:]; pkg != "models" && !strings.HasPrefix(name, prefix) {
+		name = prefix + name
+	}
+	// Name the type before reflecting its fields so self-references resolve.
+	b.names[t] = name
+	b.doc.Components.Schemas[name] = b.structSchema(t)
+	return name
+}
+
+func (b *Builder) structSchema(t reflect.Type) *Schema {
+	out := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	b.addFields(out, t)
+	sort.Strings(out.Required)
+	return out
+}
+
+func (b *Builder) addFields(out *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				b.addFields(out, embedded)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		schema := b.schemaOf(field.Type)
+		if values := field.Tag.Get("enum"); values != "" {
+			target := schema
+			if target.Type == "array" {
+				target = target.Items
+			}
+			target.Enum = strings.Split(values, ",")
+		}
+		out.Properties[name] = schema
+		if !strings.Contains(opts, "omitempty") {
+			out.Required = append(out.Required, name)
+		}
+	}
+}
+
+// JSONResponse describes a JSON body.
+func JSONResponse(description string, schema *Schema) Response {
+	return Response{Description: description, Content: map[string]MediaType{"application/json": {Schema: schema}}}
+}
+
+// TextResponse describes a non-JSON body, such as a CSV or feed download.
+func TextResponse(description string, contentTypes ...string) Response {
+	out := Response{Description: description, Content: map[string]MediaType{}}
+	for _, contentType := range contentTypes {
+		out.Content[contentType] = MediaType{Schema: &Schema{Type: "string"}}
+	}
+	return out
+}
+
+// JSONBody describes a JSON request body.
+func JSONBody(schema *Schema, required bool) *RequestBody {
+	return &RequestBody{Required: required, Content: map[string]MediaType{"application/json": {Schema: schema}}}
+}
+
+// Query describes a query parameter of the given JSON type.
+func Query(name, typ string, required bool, description string) Parameter {
+	return Parameter{Name: name, In: "query", Required: required, Description: description, Schema: &Schema{Type: typ}}
+}
+
+// Enum is a string schema limited to values.
+func Enum(values ...string) *Schema {
+	return &Schema{Type: "string", Enum: values}
+}
+
+// Routes lists every documented operation as "METHOD path", sorted.
+func (d *Document) Routes() []string {
+	var out []string
+	for path, ops := range d.Paths {
+		for method := range ops {
+			out = append(out, RouteKey(method, path))
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// RouteKey is how Routes names an operation, so router listings can be
+// compared against the document.
+func RouteKey(method, path string) string {
+	return fmt.Sprintf("%s %s", strings.ToUpper(method), path)
+}
+
+// Diff compares the routes a router serves with the documented ones and
+// returns the routes missing from the document and the operations no
+// router serves. HEAD and OPTIONS are ignored; chi adds them implicitly.
+func (d *Document) Diff(served []string) (undocumented, stale []string) {
+	documented := map[string]bool{}
+	for _, route := range d.Routes() {
+		documented[route] = true
+	}
+	seen := map[string]bool{}
+	for _, route := range served {
+		if strings.HasPrefix(route, http.MethodHead+" ") || strings.HasPrefix(route, http.MethodOptions+" ") {
+			continue
+		}
+		seen[route] = true
+		if !documented[route] {
+			undocumented = append(undocumented, route)
+		}
+	}
+	for route := range documented {
+		if !seen[route] {
+			stale = append(stale, route)
+		}
+	}
+	sort.Strings(undocumented)
+	sort.Strings(stale)
+	return undocumented, stale
+}
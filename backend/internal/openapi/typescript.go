@@ -0,0 +1,198 @@
+package openapi
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// TypeScriptHeader opens every generated TypeScript file.
+const TypeScriptHeader = "// Code generated by cmd/openapi from the API's OpenAPI document; DO NOT EDIT."
+
+var tsIdentifier = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// TypeScript renders the document as a TypeScript module: a type per
+// component, and per operation its JSON response and request body types,
+// a query type and a function that builds its URL path.
+func (d *Document) TypeScript() string {
+	var out strings.Builder
+	out.WriteString(TypeScriptHeader + "\n\n")
+	out.WriteString("export type QueryValue = string | number | boolean | undefined;\n\n")
+	out.WriteString("function withQuery(path: string, query?: Record<string, QueryValue>): string {\n")
+	out.WriteString("\tif (!query) return path;\n")
+	out.WriteString("\tconst qs = new URLSearchParams();\n")
+	out.WriteString("\tfor (const [key, value] of Object.entries(query)) {\n")
+	out.WriteString("\t\tif (value !== undefined) qs.set(key, String(value));\n")
+	out.WriteString("\t}\n")
+	out.WriteString("\tconst encoded = qs.toString();\n")
+	out.WriteString("\treturn encoded ? `${path}?${encoded}` : path;\n")
+	out.WriteString("}\n")
+
+	names := make([]string, 0, len(d.Components.Schemas))
+	for name := range d.Components.Schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(&out, "\nexport type %s = %s;\n", tsComponent(name), tsType(d.Components.Schemas[name], 0))
+	}
+
+	type route struct {
+		path, method string
+		op           Operation
+	}
+	var routes []route
+	for path, ops := range d.Paths {
+		for method, op := range ops {
+			routes = append(routes, route{path, method, op})
+		}
+	}
+	sort.Slice(routes, func(i, j int) bool { return routes[i].op.OperationID < routes[j].op.OperationID })
+
+	for _, r := range routes {
+		id := r.op.OperationID
+		typeName := strings.ToUpper(id[:1]) + id[1:]
+		fmt.Fprintf(&out, "\n// %s %s\n", strings.ToUpper(r.method), r.path)
+
+		for _, status := range []string{"200", "201"} {
+			if media, ok := r.op.Responses[status].Content["application/json"]; ok {
+				fmt.Fprintf(&out, "export type %sResponse = %s;\n", typeName, tsType(media.Schema, 0))
+				break
+			}
+		}
+		if r.op.RequestBody != nil {
+			if media, ok := r.op.RequestBody.Content["application/json"]; ok {
+				fmt.Fprintf(&out, "export type %sBody = %s;\n", typeName, tsType(media.Schema, 0))
+			}
+		}
+
+		var args []string
+		query := &Schema{Type: "object", Properties: map[string]*Schema{}}
+		for _, param := range r.op.Parameters {
+			switch param.In {
+			case "path":
+				args = append(args, fmt.Sprintf("%s: %s", param.Name, tsType(param.Schema, 0)))
+			case "query":
+				query.Properties[param.Name] = param.Schema
+				if param.Required {
+					query.Required = append(query.Required, param.Name)
+				}
+			}
+		}
+		path := pathParam.ReplaceAllStringFunc(r.path, func(match string) string {
+			name := match[1 : len(match)-1]
+			return "${encodeURIComponent(" + name + ")}"
+		})
+		if len(query.Properties) == 0 {
+			fmt.Fprintf(&out, "export function %sPath(%s): string {\n\treturn `%s`;\n}\n", id, strings.Join(args, ", "), path)
+			continue
+		}
+		optional := "?"
+		if len(query.Required) > 0 {
+			optional = ""
+		}
+		fmt.Fprintf(&out, "export type %sQuery = %s;\n", typeName, tsType(query, 0))
+		args = append(args, fmt.Sprintf("query%s: %sQuery", optional, typeName))
+		fmt.Fprintf(&out, "export function %sPath(%s): string {\n\treturn withQuery(`%s`, query);\n}\n", id, strings.Join(args, ", "), path)
+	}
+	return out.String()
+}
+
+// tsComponent keeps the shared Error component from shadowing the global
+// Error class.
+func tsComponent(name string) string {
+	if name == "Error" {
+		return "ApiError"
+	}
+	return name
+}
+
+func tsType(schema *Schema, depth int) string {
+	if schema == nil {
+		return "unknown"
+	}
+	var out string
+	switch {
+	case schema.Ref != "":
+		out = tsComponent(strings.TrimPrefix(schema.Ref, "#/components/schemas/"))
+	case len(schema.AllOf) > 0:
+		parts := make([]string, len(schema.AllOf))
+		for i, part := range schema.AllOf {
+			parts[i] = tsType(part, depth)
+		}
+		out = strings.Join(parts, " & ")
+	case len(schema.Enum) > 0:
+		values := make([]string, len(schema.Enum))
+		for i, value := range schema.Enum {
+			values[i] = tsString(value)
+		}
+		out = strings.Join(values, " | ")
+	default:
+		switch schema.Type {
+		case "string":
+			out = "string"
+		case "integer", "number":
+			out = "number"
+		case "boolean":
+			out = "boolean"
+		case "array":
+			item := tsType(schema.Items, depth)
+			if strings.ContainsAny(item, "|&{") {
+				out = "Array<" + item + ">"
+			} else {
+				out = item + "[]"
+			}
+		case "object":
+			out = tsObject(schema, depth)
+		default:
+			out = "unknown"
+		}
+	}
+	if schema.Nullable {
+		out += " | null"
+	}
+	return out
+}
+
+func tsObject(schema *Schema, depth int) string {
+	var parts []string
+	if len(schema.Properties) > 0 {
+		required := map[string]bool{}
+		for _, name := range schema.Required {
+			required[name] = true
+		}
+		names := make([]string, 0, len(schema.Properties))
+		for name := range schema.Properties {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		indent := strings.Repeat("\t", depth+1)
+		var body strings.Builder
+		body.WriteString("{\n")
+		for _, name := range names {
+			key := name
+			if !tsIdentifier.MatchString(key) {
+				key = tsString(key)
+			}
+			if !required[name] {
+				key += "?"
+			}
+			fmt.Fprintf(&body, "%s%s: %s;\n", indent, key, tsType(schema.Properties[name], depth+1))
+		}
+		body.WriteString(strings.Repeat("\t", depth) + "}")
+		parts = append(parts, body.String())
+	}
+	if schema.AdditionalProperties != nil {
+		parts = append(parts, "Record<string, "+tsType(schema.AdditionalProperties, depth)+">")
+	}
+	if len(parts) == 0 {
+		return "Record<string, unknown>"
+	}
+	return strings.Join(parts, " & ")
+}
+
+func tsString(value string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value) + "'"
+}
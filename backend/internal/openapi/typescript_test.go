@@ -0,0 +1,57 @@
+package openapi
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTypeScript(t *testing.T) {
+	doc := testDocument()
+	doc.Paths["/games/{gameID}"]["get"] = func() Operation {
+		op := doc.Paths["/games/{gameID}"]["get"]
+		op.Parameters = append(op.Parameters, Query("memberId", "string", false, ""))
+		return op
+	}()
+	out := doc.TypeScript()
+
+	for _, want := range []string{
+		TypeScriptHeader,
+		"export type ApiError = {\n\terror: string;\n};",
+		"export type GetGameResponse = {\n\tgame: {\n\t\thome: {\n\t\t\tcode: string;\n\t\t};\n",
+		"\t\tkickoff: string | null;\n",
+		"\t\ttags: string[];\n",
+		"\t\twinner?: string | null;\n",
+		"export type GetGameQuery = {\n\tmemberId?: string;\n};",
+		"export function getGamePath(gameID: string, query?: GetGameQuery): string {\n\treturn withQuery(`/games/${encodeURIComponent(gameID)}`, query);\n}",
+		"export function getCurrentGamePath(): string {\n\treturn `/games/current`;\n}",
+		"export function exportGamePath(gameID: string): string {",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output is missing %q\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "export type ExportGameResponse") {
+		t.Error("text responses should not get a response type")
+	}
+}
+
+func TestTSType(t *testing.T) {
+	tests := []struct {
+		schema *Schema
+		want   string
+	}{
+		{&Schema{Type: "integer"}, "number"},
+		{&Schema{Type: "string", Enum: []string{"home", "it's"}}, `'home' | 'it\'s'`},
+		{&Schema{Type: "array", Items: &Schema{Type: "string", Enum: []string{"a", "b"}}}, "Array<'a' | 'b'>"},
+		{&Schema{Type: "object", AdditionalProperties: &Schema{Type: "number"}}, "Record<string, number>"},
+		{&Schema{Type: "object"}, "Record<string, unknown>"},
+		{&Schema{AllOf: []*Schema{{Ref: "#/components/schemas/Week"}}, Nullable: true}, "Week | null"},
+		{&Schema{Ref: "#/components/schemas/Error"}, "ApiError"},
+		{&Schema{}, "unknown"},
+	}
+	for _, tt := range tests {
+		if got := tsType(tt.schema, 0); got != tt.want {
+			t.Errorf("tsType(%+v) = %q, want %q", tt.schema, got, tt.want)
+		}
+	}
+}
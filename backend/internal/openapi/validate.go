@@ -0,0 +1,211 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Match finds the documented path template that serves urlPath for
+// method. Literal segments beat parameters, so /weeks/current is not read
+// as /weeks/{weekNumber}.
+func (d *Document) Match(method, urlPath string) (string, bool) {
+	method = strings.ToLower(method)
+	best, bestParams := "", -1
+	for template, ops := range d.Paths {
+		if _, ok := ops[method]; !ok {
+			continue
+		}
+		if !templateMatches(template, urlPath) {
+			continue
+		}
+		params := len(pathParam.FindAllString(template, -1))
+		if bestParams == -1 || params < bestParams {
+			best, bestParams = template, params
+		}
+	}
+	return best, bestParams >= 0
+}
+
+func templateMatches(template, urlPath string) bool {
+	want := strings.Split(template, "/")
+	got := strings.Split(urlPath, "/")
+	if len(want) != len(got) {
+		return false
+	}
+	for i, segment := range want {
+		if pathParam.MatchString(segment) {
+			if got[i] == "" {
+				return false
+			}
+			continue
+		}
+		if segment != got[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidateResponse checks a response against the document: the status must
+// be documented for the operation (or covered by its default), the content
+// type must be one the response lists, and JSON bodies must satisfy the
+// schema.
+func (d *Document) ValidateResponse(method, urlPath string, status int, contentType string, body []byte) error {
+	template, ok := d.Match(method, urlPath)
+	if !ok {
+		return fmt.Errorf("openapi: %s %s is not documented", method, urlPath)
+	}
+	op := d.Paths[template][strings.ToLower(method)]
+	response, ok := op.Responses[strconv.Itoa(status)]
+	if !ok {
+		if response, ok = op.Responses["default"]; !ok || status < 400 {
+			return fmt.Errorf("openapi: %s %s: status %d is not documented", method, template, status)
+		}
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return fmt.Errorf("openapi: %s %s: content type %q: %w", method, template, contentType, err)
+	}
+	media, ok := response.Content[mediaType]
+	if !ok {
+		return fmt.Errorf("openapi: %s %s: status %d does not document %s", method, template, status, mediaType)
+	}
+	if mediaType != "application/json" {
+		return nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var value any
+	if err := dec.Decode(&value); err != nil {
+		return fmt.Errorf("openapi: %s %s: decode body: %w", method, template, err)
+	}
+	if err := d.Validate(media.Schema, value); err != nil {
+		return fmt.Errorf("openapi: %s %s: status %d: %w", method, template, status, err)
+	}
+	return nil
+}
+
+// Validate checks a decoded JSON value (numbers as json.Number) against a
+// schema. Objects with declared properties reject keys they do not
+// declare, so a field added to a response without a schema change fails.
+func (d *Document) Validate(schema *Schema, value any) error {
+	var problems []string
+	d.validate(schema, value, "$", &problems)
+	if len(problems) == 0 {
+		return nil
+	}
+	sort.Strings(problems)
+	return fmt.Errorf("%s", strings.Join(problems, "; "))
+}
+
+func (d *Document) validate(schema *Schema, value any, at string, problems *[]string) {
+	if schema == nil {
+		return
+	}
+	if schema.Ref != "" {
+		name := strings.TrimPrefix(schema.Ref, "#/components/schemas/")
+		resolved, ok := d.Components.Schemas[name]
+		if !ok {
+			*problems = append(*problems, fmt.Sprintf("%s: unknown schema %s", at, schema.Ref))
+			return
+		}
+		d.validate(resolved, value, at, problems)
+		return
+	}
+	if value == nil {
+		if !schema.Nullable && (schema.Type != "" || len(schema.AllOf) > 0) {
+			*problems = append(*problems, fmt.Sprintf("%s: null is not allowed", at))
+		}
+		return
+	}
+	for _, part := range schema.AllOf {
+		d.validate(part, value, at, problems)
+	}
+
+	fail := func(format string, args ...any) {
+		*problems = append(*problems, at+": "+fmt.Sprintf(format, args...))
+	}
+	switch schema.Type {
+	case "object":
+		object, ok := value.(map[string]any)
+		if !ok {
+			fail("want an object, got %T", value)
+			return
+		}
+		for _, name := range schema.Required {
+			if _, ok := object[name]; !ok {
+				fail("missing required property %q", name)
+			}
+		}
+		for name, field := range object {
+			if property, ok := schema.Properties[name]; ok {
+				d.validate(property, field, at+"."+name, problems)
+				continue
+			}
+			if schema.AdditionalProperties != nil {
+				d.validate(schema.AdditionalProperties, field, at+"."+name, problems)
+				continue
+			}
+			if len(schema.Properties) > 0 {
+				fail("undocumented property %q", name)
+			}
+		}
+	case "array":
+		items, ok := value.([]any)
+		if !ok {
+			fail("want an array, got %T", value)
+			return
+		}
+		for i, item := range items {
+			d.validate(schema.Items, item, fmt.Sprintf("%s[%d]", at, i), problems)
+		}
+	case "string":
+		text, ok := value.(string)
+		if !ok {
+			fail("want a string, got %T", value)
+			return
+		}
+		if len(schema.Enum) > 0 && !contains(schema.Enum, text) {
+			fail("%q is not one of %v", text, schema.Enum)
+		}
+		if schema.Format == "date-time" {
+			if _, err := time.Parse(time.RFC3339Nano, text); err != nil {
+				fail("%q is not a date-time", text)
+			}
+		}
+	case "integer":
+		number, ok := value.(json.Number)
+		if !ok {
+			fail("want an integer, got %T", value)
+			return
+		}
+		if _, err := number.Int64(); err != nil {
+			fail("%s is not an integer", number)
+		}
+	case "number":
+		if _, ok := value.(json.Number); !ok {
+			fail("want a number, got %T", value)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			fail("want a boolean, got %T", value)
+		}
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
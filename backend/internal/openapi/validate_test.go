@@ -0,0 +1,196 @@
+package openapi
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type testTeam struct {
+	Code string `json:"code"`
+}
+
+type testGame struct {
+	ID      string     `json:"id"`
+	Kickoff *time.Time `json:"kickoff"`
+	Home    testTeam   `json:"home"`
+	Winner  *string    `json:"winner,omitempty"`
+	Score   int        `json:"score"`
+	Tags    []string   `json:"tags"`
+}
+
+func testDocument() *Document {
+	b := NewBuilder("test", "1")
+	b.Add("GET", "/games/{gameID}", Operation{
+		OperationID: "getGame",
+		Responses:   map[string]Response{"200": JSONResponse("Game", b.Wrap("game", testGame{}))},
+	})
+	b.Add("GET", "/games/current", Operation{
+		OperationID: "getCurrentGame",
+		Responses:   map[string]Response{"200": JSONResponse("Week", b.Wrap("weekNumber", 0))},
+	})
+	b.Add("GET", "/games/{gameID}/export.csv", Operation{
+		OperationID: "exportGame",
+		Responses:   map[string]Response{"200": TextResponse("CSV", "text/csv")},
+	})
+	return b.Document()
+}
+
+func TestMatch(t *testing.T) {
+	doc := testDocument()
+	tests := []struct {
+		method, path, want string
+		ok                 bool
+	}{
+		{"GET", "/games/abc", "/games/{gameID}", true},
+		{"get", "/games/current", "/games/current", true},
+		{"GET", "/games/abc/export.csv", "/games/{gameID}/export.csv", true},
+		{"GET", "/games/", "", false},
+		{"POST", "/games/abc", "", false},
+		{"GET", "/teams/abc", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := doc.Match(tt.method, tt.path)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("Match(%s %s) = %q, %v; want %q, %v", tt.method, tt.path, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestValidateResponse(t *testing.T) {
+	doc := testDocument()
+	tests := []struct {
+		name        string
+		path        string
+		status      int
+		contentType string
+		body        string
+		wantErr     string
+	}{
+		{
+			name:        "valid",
+			path:        "/games/g1",
+			status:      200,
+			contentType: "application/json; charset=utf-8",
+			body:        `{"game":{"id":"g1","kickoff":"2025-09-07T17:00:00Z","home":{"code":"BUF"},"score":3,"tags":[]}}`,
+		},
+		{
+			name:        "nullable and optional fields",
+			path:        "/games/g1",
+			status:      200,
+			contentType: "application/json",
+			body:        `{"game":{"id":"g1","kickoff":null,"home":{"code":"BUF"},"winner":"home","score":0,"tags":["upset"]}}`,
+		},
+		{
+			name:        "null array",
+			path:        "/games/g1",
+			status:      200,
+			contentType: "application/json",
+			body:        `{"game":{"id":"g1","kickoff":null,"home":{"code":"BUF"},"score":0,"tags":null}}`,
+			wantErr:     "$.game.tags: null is not allowed",
+		},
+		{
+			name:        "missing required property",
+			path:        "/games/g1",
+			status:      200,
+			contentType: "application/json",
+			body:        `{"game":{"id":"g1","kickoff":null,"home":{},"score":0,"tags":[]}}`,
+			wantErr:     `$.game.home: missing required property "code"`,
+		},
+		{
+			name:        "undocumented property",
+			path:        "/games/g1",
+			status:      200,
+			contentType: "application/json",
+			body:        `{"game":{"id":"g1","kickoff":null,"home":{"code":"BUF"},"score":0,"tags":[],"spread":3.5}}`,
+			wantErr:     `undocumented property "spread"`,
+		},
+		{
+			name:        "wrong types",
+			path:        "/games/g1",
+			status:      200,
+			contentType: "application/json",
+			body:        `{"game":{"id":1,"kickoff":"soon","home":{"code":"BUF"},"score":1.5,"tags":[]}}`,
+			wantErr:     `$.game.id: want a string`,
+		},
+		{
+			name:        "documented error",
+			path:        "/games/g1",
+			status:      404,
+			contentType: "application/json",
+			body:        `{"error":"game not found"}`,
+		},
+		{
+			name:        "error with the wrong shape",
+			path:        "/games/g1",
+			status:      500,
+			contentType: "application/json",
+			body:        `{"message":"boom"}`,
+			wantErr:     `missing required property "error"`,
+		},
+		{
+			name:        "undocumented success status",
+			path:        "/games/g1",
+			status:      201,
+			contentType: "application/json",
+			body:        `{}`,
+			wantErr:     "status 201 is not documented",
+		},
+		{
+			name:        "undocumented content type",
+			path:        "/games/g1",
+			status:      200,
+			contentType: "text/plain",
+			body:        `ok`,
+			wantErr:     "does not document text/plain",
+		},
+		{
+			name:        "text body",
+			path:        "/games/g1/export.csv",
+			status:      200,
+			contentType: "text/csv; charset=utf-8",
+			body:        "a,b\n",
+		},
+		{
+			name:        "literal route",
+			path:        "/games/current",
+			status:      200,
+			contentType: "application/json",
+			body:        `{"weekNumber":3}`,
+		},
+		{
+			name:    "undocumented route",
+			path:    "/teams/BUF",
+			status:  200,
+			wantErr: "is not documented",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := doc.ValidateResponse("GET", tt.path, tt.status, tt.contentType, []byte(tt.body))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateReportsEveryProblem(t *testing.T) {
+	doc := testDocument()
+	err := doc.ValidateResponse("GET", "/games/g1", 200, "application/json",
+		[]byte(`{"game":{"id":1,"kickoff":"soon","home":{"code":"BUF"},"score":1.5,"tags":[2]}}`))
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	for _, want := range []string{"$.game.id", "$.game.kickoff", "$.game.score", "$.game.tags[0]"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %s", err, want)
+		}
+	}
+}
@@ -0,0 +1,214 @@
+package pickparse
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"pickem/backend/internal/models"
+	"pickem/backend/internal/nfl"
+)
+
+// Issue kinds reported on models.PickParseIssue.
+const (
+	IssueUnknown    = "unknown"     // matches no team
+	IssueNotPlaying = "not-playing" // a real team without a game this week
+	IssueAmbiguous  = "ambiguous"   // more than one team playing this week
+	IssueConflict   = "conflict"    // both sides of the same game picked
+)
+
+// maxPhrase is the longest run of words tried as one team ("new york jets").
+const maxPhrase = 3
+
+var (
+	separators = regexp.MustCompile(`(?i)[,;/\n\r\t&+]+|\band\b`)
+	number     = regexp.MustCompile(`^\d+$`)
+)
+
+// fillers are words people wrap their picks in that never name a team.
+var fillers = map[string]bool{
+	"the": true, "over": true, "vs": true, "at": true, "pick": true,
+	"picks": true, "take": true, "taking": true, "go": true, "with": true,
+	"tiebreaker": true, "tiebreak": true, "total": true, "points": true,
+	"pts": true, "my": true, "are": true, "is": true, "for": true,
+	"none": true, "tiebreakers": true, "this": true, "week": true,
+}
+
+// wordCodes are team codes that are also everyday words ("no tiebreaker",
+// "was going to say"). They only name a team when written in capitals, as
+// in "NO" or "WAS"; otherwise they are skipped like fillers.
+var wordCodes = map[string]bool{
+	"no": true, "was": true, "ten": true, "car": true, "sea": true,
+	"den": true, "min": true,
+}
+
+// Parse reads a free-form pick message ("Bills, KC, Lions, Niners, 45")
+// against the week's games. Each team is matched by code, name, city or a
+// common nickname; a bare number is taken as the tie-breaker (the last one
+// wins). Anything that cannot be settled to a single side of a single game
+// is reported as an issue instead of guessed at.
+func Parse(message string, games []models.Game) models.PickParseResult {
+	result := models.PickParseResult{
+		Message: message,
+		Picks:   []models.ParsedPick{},
+		Issues:  []models.PickParseIssue{},
+		Missing: []string{},
+	}
+
+	sides := map[string]side{}
+	for _, game := range games {
+		sides[game.HomeTeam.Code] = side{game: game, side: "home", team: game.HomeTeam}
+		sides[game.AwayTeam.Code] = side{game: game, side: "away", team: game.AwayTeam}
+	}
+
+	chosen := map[string]int{}
+	conflicted := map[string]bool{}
+	for _, segment := range separators.Split(message, -1) {
+		raw := strings.FieldsFunc(segment, func(r rune) bool {
+			return r == ' ' || r == '.' || r == '!' || r == '?' || r == ':' || r == '-' || r == '(' || r == ')'
+		})
+		words := make([]string, len(raw))
+		for i, word := range raw {
+			words[i] = strings.ToLower(word)
+		}
+		for i := 0; i < len(words); {
+			word := words[i]
+			if number.MatchString(word) {
+				if points, err := strconv.Atoi(word); err == nil {
+					result.TieBreaker = &points
+				}
+				i++
+				continue
+			}
+			if fillers[word] || (wordCodes[word] && raw[i] != strings.ToUpper(raw[i])) {
+				i++
+				continue
+			}
+
+			text, playing, teams := matchPhrase(words[i:], sides)
+			i += len(strings.Fields(text))
+
+			switch {
+			case len(teams) == 0:
+				result.Issues = append(result.Issues, models.PickParseIssue{Text: text, Kind: IssueUnknown})
+			case len(playing) == 0:
+				result.Issues = append(result.Issues, models.PickParseIssue{
+					Text:       text,
+					Kind:       IssueNotPlaying,
+					Candidates: teamInfos(teams),
+				})
+			case len(playing) > 1:
+				candidates := make([]models.TeamInfo, 0, len(playing))
+				for _, s := range playing {
+					candidates = append(candidates, s.team)
+				}
+				result.Issues = append(result.Issues, models.PickParseIssue{
+					Text:       text,
+					Kind:       IssueAmbiguous,
+					Candidates: candidates,
+				})
+			default:
+				pick := playing[0]
+				key := pick.game.GameKey
+				if idx, ok := chosen[key]; ok {
+					if result.Picks[idx].Side != pick.side && !conflicted[key] {
+						conflicted[key] = true
+						result.Issues = append(result.Issues, models.PickParseIssue{
+							Text:       text,
+							Kind:       IssueConflict,
+							Candidates: []models.TeamInfo{pick.game.AwayTeam, pick.game.HomeTeam},
+						})
+					}
+					continue
+				}
+				chosen[key] = len(result.Picks)
+				result.Picks = append(result.Picks, models.ParsedPick{
+					Text:    text,
+					GameKey: key,
+					Side:    pick.side,
+					Team:    pick.team,
+				})
+			}
+		}
+	}
+
+	// A game picked both ways is left out rather than resolved either way.
+	if len(conflicted) > 0 {
+		kept := result.Picks[:0]
+		for _, pick := range result.Picks {
+			if !conflicted[pick.GameKey] {
+				kept = append(kept, pick)
+			}
+		}
+		result.Picks = kept
+	}
+
+	picked := map[string]bool{}
+	for _, pick := range result.Picks {
+		picked[pick.GameKey] = true
+	}
+	for _, game := range games {
+		if !picked[game.GameKey] && game.Status != models.GameStatusCanceled {
+			result.Missing = append(result.Missing, game.GameKey)
+		}
+	}
+
+	return result
+}
+
+type side struct {
+	game models.Game
+	side string
+	team models.TeamInfo
+}
+
+// matchPhrase tries the longest run of words first so "new york jets" is
+// read as one team rather than the ambiguous "new york" and a stray "jets".
+// Only single words are matched loosely; a fuzzy multi-word match would
+// swallow the next team ("buffalo kc"). It returns the text consumed, the matched teams playing this week, and
+// every team the text could mean.
+func matchPhrase(words []string, sides map[string]side) (string, []side, []nfl.Team) {
+	longest := min(maxPhrase, len(words))
+	for n := longest; n >= 1; n-- {
+		text := strings.Join(words[:n], " ")
+		match := nfl.Match
+		if n > 1 {
+			match = nfl.MatchExact
+		}
+		teams := match(text)
+		if len(teams) == 0 {
+			continue
+		}
+		playing := []side{}
+		for _, team := range teams {
+			if s, ok := sides[team.Code]; ok {
+				playing = append(playing, s)
+			}
+		}
+		// A longer phrase only wins when it narrows things down; otherwise
+		// fall through and let the words stand on their own.
+		if n > 1 && len(playing) != 1 {
+			continue
+		}
+		// "ny jets": an ambiguous word settled by the one after it.
+		if len(playing) > 1 && len(words) > 1 {
+			for _, next := range nfl.Match(words[1]) {
+				for _, s := range playing {
+					if s.team.Code == next.Code {
+						return text + " " + words[1], []side{s}, []nfl.Team{next}
+					}
+				}
+			}
+		}
+		return text, playing, teams
+	}
+	return words[0], nil, nil
+}
+
+func teamInfos(teams []nfl.Team) []models.TeamInfo {
+	infos := make([]models.TeamInfo, 0, len(teams))
+	for _, team := range teams {
+		infos = append(infos, models.TeamInfo{Code: team.Code, Name: team.Name, Location: team.Location})
+	}
+	return infos
+}
@@ -0,0 +1,111 @@
+package pickparse
+
+import (
+	"reflect"
+	"testing"
+
+	"pickem/backend/internal/models"
+)
+
+func testGames() []models.Game {
+	game := func(key, away, awayName, home, homeName string) models.Game {
+		return models.Game{
+			GameKey:  key,
+			Status:   models.GameStatusScheduled,
+			AwayTeam: models.TeamInfo{Code: away, Name: awayName},
+			HomeTeam: models.TeamInfo{Code: home, Name: homeName},
+		}
+	}
+	return []models.Game{
+		game("g1", "BUF", "Bills", "NO", "Saints"),
+		game("g2", "KC", "Chiefs", "NYJ", "Jets"),
+		game("g3", "NYG", "Giants", "WAS", "Commanders"),
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name       string
+		message    string
+		picks      map[string]string
+		issues     []string
+		tieBreaker *int
+	}{
+		{
+			name:    "lowercase no is a word, not the Saints",
+			message: "No tiebreaker. Bills",
+			picks:   map[string]string{"g1": "away"},
+		},
+		{
+			name:    "capitalised NO is the Saints",
+			message: "NO, KC, Giants",
+			picks:   map[string]string{"g1": "home", "g2": "away", "g3": "away"},
+		},
+		{
+			name:    "lowercase was is a word",
+			message: "Jets was my pick",
+			picks:   map[string]string{"g2": "home"},
+		},
+		{
+			name:    "capitalised WAS is Washington",
+			message: "WAS",
+			picks:   map[string]string{"g3": "home"},
+		},
+		{
+			name:       "tie-breaker and multi-word teams",
+			message:    "buffalo kc and new york giants 45",
+			picks:      map[string]string{"g1": "away", "g2": "away", "g3": "away"},
+			tieBreaker: intPtr(45),
+		},
+		{
+			name:    "ambiguous city settled by the next word",
+			message: "ny jets",
+			picks:   map[string]string{"g2": "home"},
+		},
+		{
+			name:    "both sides of one game",
+			message: "Bills, Saints",
+			picks:   map[string]string{},
+			issues:  []string{IssueConflict},
+		},
+		{
+			name:    "team without a game",
+			message: "Packers",
+			picks:   map[string]string{},
+			issues:  []string{IssueNotPlaying},
+		},
+		{
+			name:    "unknown word",
+			message: "zzzz",
+			picks:   map[string]string{},
+			issues:  []string{IssueUnknown},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Parse(tt.message, testGames())
+
+			picks := map[string]string{}
+			for _, pick := range result.Picks {
+				picks[pick.GameKey] = pick.Side
+			}
+			if !reflect.DeepEqual(picks, tt.picks) {
+				t.Errorf("picks = %v, want %v", picks, tt.picks)
+			}
+
+			var issues []string
+			for _, issue := range result.Issues {
+				issues = append(issues, issue.Kind)
+			}
+			if !reflect.DeepEqual(issues, tt.issues) {
+				t.Errorf("issues = %v, want %v", issues, tt.issues)
+			}
+
+			if !reflect.DeepEqual(result.TieBreaker, tt.tieBreaker) {
+				t.Errorf("tie-breaker = %v, want %v", result.TieBreaker, tt.tieBreaker)
+			}
+		})
+	}
+}
+
+func intPtr(n int) *int { return &n }
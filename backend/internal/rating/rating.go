@@ -0,0 +1,137 @@
+package rating
+
+import (
+	"sort"
+
+	"pickem/backend/internal/models"
+	"pickem/backend/internal/stats"
+)
+
+const (
+	// Initial is every member's rating before their first graded week.
+	Initial = 1500.0
+	// K scales how far a single pick moves a rating.
+	K = 8.0
+)
+
+// Entry is a member's rating after one final week.
+type Entry struct {
+	SeasonID   string
+	WeekID     string
+	WeekNumber int
+	MemberID   string
+	Rating     float64
+	Change     float64
+	Games      int
+}
+
+// Compute replays every final week in order, oldest season first. Each
+// graded pick is scored against the share of the rest of the family that
+// got the game right, so a correct call the others missed earns nearly K
+// and a miss everyone else avoided costs nearly K. Pushes score a half.
+// Ratings carry over between seasons. Weeks with games still to be graded
+// are skipped until they finish.
+func Compute(seasons []stats.Season) []Entry {
+	ratings := map[string]float64{}
+	entries := []Entry{}
+
+	for _, season := range seasons {
+		for _, week := range season.Weeks {
+			if !final(week.Games) {
+				continue
+			}
+
+			changes := map[string]float64{}
+			games := map[string]int{}
+			for _, game := range week.Games {
+				for _, pick := range game.Picks {
+					score, ok := pickScore(pick.Status)
+					if !ok {
+						continue
+					}
+					expected, ok := expectedScore(game, pick.MemberID)
+					if !ok {
+						continue
+					}
+					changes[pick.MemberID] += K * (score - expected)
+					games[pick.MemberID]++
+				}
+			}
+
+			memberIDs := make([]string, 0, len(games))
+			for memberID := range games {
+				memberIDs = append(memberIDs, memberID)
+			}
+			sort.Strings(memberIDs)
+
+			for _, memberID := range memberIDs {
+				current, ok := ratings[memberID]
+				if !ok {
+					current = Initial
+				}
+				ratings[memberID] = current + changes[memberID]
+				entries = append(entries, Entry{
+					SeasonID:   season.ID,
+					WeekID:     week.ID,
+					WeekNumber: week.Number,
+					MemberID:   memberID,
+					Rating:     ratings[memberID],
+					Change:     changes[memberID],
+					Games:      games[memberID],
+				})
+			}
+		}
+	}
+
+	return entries
+}
+
+// expectedScore is the share of the other members' graded picks on the game
+// that scored, with pushes counting a half. It reports false when nobody
+// else picked the game, since there is nothing to measure against.
+func expectedScore(game models.Game, memberID string) (float64, bool) {
+	total, count := 0.0, 0
+	for _, pick := range game.Picks {
+		if pick.MemberID == memberID {
+			continue
+		}
+		score, ok := pickScore(pick.Status)
+		if !ok {
+			continue
+		}
+		total += score
+		count++
+	}
+	if count == 0 {
+		return 0, false
+	}
+	return total / float64(count), true
+}
+
+func pickScore(status string) (float64, bool) {
+	switch status {
+	case models.PickStatusCorrect:
+		return 1, true
+	case models.PickStatusIncorrect:
+		return 0, true
+	case models.PickStatusPush:
+		return 0.5, true
+	}
+	return 0, false
+}
+
+// final reports whether every game in the week is graded or canceled, with
+// at least one graded.
+func final(games []models.Game) bool {
+	graded := false
+	for _, game := range games {
+		switch game.Status {
+		case models.GameStatusFinal, models.GameStatusForfeit:
+			graded = true
+		case models.GameStatusCanceled:
+		default:
+			return false
+		}
+	}
+	return graded
+}
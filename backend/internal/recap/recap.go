@@ -0,0 +1,256 @@
+package recap
+
+import (
+	"sort"
+
+	"pickem/backend/internal/models"
+	"pickem/backend/internal/odds"
+)
+
+// highlightLimit caps the best picks, worst picks and upsets in a recap.
+const highlightLimit = 3
+
+// Input is everything the recap draws on for one week.
+type Input struct {
+	Season models.Season
+	Week   models.Week
+	// Games should carry their stored tags for the highlights.
+	Games  []models.Game
+	Result *models.WeekResult
+	// Members carries each member's graded record and tie-breaker guess for
+	// the week.
+	Members []models.RecapMember
+	// TieBreakerGame is the game whose combined score settles tied weeks.
+	TieBreakerGame *models.Game
+	Standings      *models.Standings
+}
+
+// Build assembles the week's recap. Weeks that have not been declared still
+// get a recap, just without a winner.
+func Build(input Input) models.WeekRecap {
+	recap := models.WeekRecap{
+		SeasonID:    input.Season.ID,
+		SeasonLabel: input.Season.Label,
+		WeekNumber:  input.Week.Number,
+		WeekLabel:   input.Week.Label,
+		Leaderboard: []models.RecapMember{},
+		BestPicks:   []models.RecapPick{},
+		WorstPicks:  []models.RecapPick{},
+		Upsets:      []models.RecapUpset{},
+		Highlights:  []models.RecapHighlight{},
+		Standings:   []models.StandingsEntry{},
+	}
+
+	names := map[string]string{}
+	for _, member := range input.Members {
+		names[member.MemberID] = member.Name
+		record := member.Record
+		if record.Wins+record.Losses+record.Ties == 0 && member.TieBreaker == nil {
+			continue
+		}
+		recap.Leaderboard = append(recap.Leaderboard, member)
+	}
+	sort.SliceStable(recap.Leaderboard, func(i, j int) bool {
+		a, b := recap.Leaderboard[i], recap.Leaderboard[j]
+		if a.Record.Points != b.Record.Points {
+			return a.Record.Points > b.Record.Points
+		}
+		return a.Name < b.Name
+	})
+
+	if input.Result != nil {
+		recap.Declared = true
+		recap.Notes = input.Result.Notes
+		for i := range input.Members {
+			if input.Members[i].MemberID == input.Result.WinnerMemberID {
+				winner := input.Members[i]
+				recap.Winner = &winner
+				break
+			}
+		}
+	}
+
+	if game := input.TieBreakerGame; game != nil {
+		recap.TieBreaker = tieBreaker(*game, recap.Winner, recap.Leaderboard)
+	}
+
+	for _, game := range input.Games {
+		picks := gradedPicks(game)
+		for _, pick := range picks {
+			shared := 0
+			for _, other := range picks {
+				if other.ChosenSide == pick.ChosenSide {
+					shared++
+				}
+			}
+			// Only minority picks are notable: a lone correct call, or a
+			// miss most of the family avoided.
+			if shared*2 >= len(picks) {
+				continue
+			}
+			team, opponent := game.HomeTeam.Code, game.AwayTeam.Code
+			if pick.ChosenSide == "away" {
+				team, opponent = opponent, team
+			}
+			entry := models.RecapPick{
+				MemberID: pick.MemberID,
+				Name:     names[pick.MemberID],
+				GameKey:  game.GameKey,
+				Team:     team,
+				Opponent: opponent,
+				Correct:  pick.Status == models.PickStatusCorrect,
+				Shared:   shared,
+				Picks:    len(picks),
+			}
+			if entry.Correct {
+				recap.BestPicks = append(recap.BestPicks, entry)
+			} else {
+				recap.WorstPicks = append(recap.WorstPicks, entry)
+			}
+		}
+
+		if upset, ok := upsetFor(game, picks); ok {
+			recap.Upsets = append(recap.Upsets, upset)
+		}
+	}
+
+	for _, list := range [][]models.RecapPick{recap.BestPicks, recap.WorstPicks} {
+		sort.SliceStable(list, func(i, j int) bool {
+			if list[i].Shared != list[j].Shared {
+				return list[i].Shared < list[j].Shared
+			}
+			return list[i].Name < list[j].Name
+		})
+	}
+	recap.BestPicks = limit(recap.BestPicks)
+	recap.WorstPicks = limit(recap.WorstPicks)
+
+	sort.SliceStable(recap.Upsets, func(i, j int) bool {
+		a, b := recap.Upsets[i], recap.Upsets[j]
+		if a.Fooled != b.Fooled {
+			return a.Fooled > b.Fooled
+		}
+		return a.ByLine && !b.ByLine
+	})
+	if len(recap.Upsets) > highlightLimit {
+		recap.Upsets = recap.Upsets[:highlightLimit]
+	}
+
+	for _, tag := range []string{models.GameTagDecidedWeek, models.GameTagBiggestUpset, models.GameTagClosestGame} {
+		for _, game := range input.Games {
+			if hasTag(game, tag) {
+				recap.Highlights = append(recap.Highlights, models.RecapHighlight{
+					Tag:       tag,
+					GameKey:   game.GameKey,
+					HomeTeam:  game.HomeTeam.Code,
+					AwayTeam:  game.AwayTeam.Code,
+					HomeScore: game.HomeScore,
+					AwayScore: game.AwayScore,
+				})
+			}
+		}
+	}
+
+	if input.Standings != nil {
+		recap.Standings = input.Standings.Entries
+	}
+
+	return recap
+}
+
+// tieBreaker reports the tie-breaker game and, when the winner finished
+// level on points with someone, how close their guess came.
+func tieBreaker(game models.Game, winner *models.RecapMember, leaderboard []models.RecapMember) *models.RecapTieBreaker {
+	result := &models.RecapTieBreaker{
+		GameKey:  game.GameKey,
+		HomeTeam: game.HomeTeam.Code,
+		AwayTeam: game.AwayTeam.Code,
+	}
+	if game.HomeScore != nil && game.AwayScore != nil && (game.Status == models.GameStatusFinal || game.Status == models.GameStatusForfeit) {
+		total := *game.HomeScore + *game.AwayScore
+		result.ActualTotal = &total
+	}
+	if winner == nil {
+		return result
+	}
+
+	for _, member := range leaderboard {
+		if member.MemberID != winner.MemberID && member.Record.Points == winner.Record.Points {
+			result.Decided = true
+			break
+		}
+	}
+	if winner.TieBreaker != nil {
+		guess := *winner.TieBreaker
+		result.WinnerGuess = &guess
+		if result.ActualTotal != nil {
+			margin := guess - *result.ActualTotal
+			if margin < 0 {
+				margin = -margin
+			}
+			result.Margin = &margin
+		}
+	}
+	return result
+}
+
+// upsetFor flags a graded game the favorite lost, or that most of the
+// family got wrong.
+func upsetFor(game models.Game, picks []models.GamePick) (models.RecapUpset, bool) {
+	if game.Winner != "home" && game.Winner != "away" {
+		return models.RecapUpset{}, false
+	}
+
+	fooled := 0
+	for _, pick := range picks {
+		if pick.Status == models.PickStatusIncorrect {
+			fooled++
+		}
+	}
+	favorite := odds.Favorite(game)
+	byLine := favorite != "" && favorite != game.Winner
+	if !byLine && fooled*2 <= len(picks) {
+		return models.RecapUpset{}, false
+	}
+
+	winner, loser := game.HomeTeam.Code, game.AwayTeam.Code
+	if game.Winner == "away" {
+		winner, loser = loser, winner
+	}
+	return models.RecapUpset{
+		GameKey:   game.GameKey,
+		Winner:    winner,
+		Loser:     loser,
+		HomeScore: game.HomeScore,
+		AwayScore: game.AwayScore,
+		ByLine:    byLine,
+		Fooled:    fooled,
+		Picks:     len(picks),
+	}, true
+}
+
+func hasTag(game models.Game, tag string) bool {
+	for _, t := range game.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+func gradedPicks(game models.Game) []models.GamePick {
+	picks := []models.GamePick{}
+	for _, pick := range game.Picks {
+		if pick.Status == models.PickStatusCorrect || pick.Status == models.PickStatusIncorrect {
+			picks = append(picks, pick)
+		}
+	}
+	return picks
+}
+
+func limit(picks []models.RecapPick) []models.RecapPick {
+	if len(picks) > highlightLimit {
+		return picks[:highlightLimit]
+	}
+	return picks
+}
@@ -0,0 +1,102 @@
+package recap
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"pickem/backend/internal/models"
+)
+
+// Output formats understood by Render.
+const (
+	FormatMarkdown = "markdown"
+	FormatText     = "text"
+)
+
+var ErrUnknownFormat = errors.New("recap: unknown format")
+
+//go:embed templates/*.tmpl
+var templateFiles embed.FS
+
+var templates = template.Must(template.New("recap").Funcs(template.FuncMap{
+	"record":   formatRecord,
+	"movement": formatMovement,
+	"points":   formatPoints,
+	"score":    formatScore,
+	"matchup":  formatMatchup,
+	"tag":      formatTag,
+	"inc":      func(i int) int { return i + 1 },
+	"deref":    func(v *int) int { return *v },
+}).ParseFS(templateFiles, "templates/*.tmpl"))
+
+// Render writes the recap in the given format.
+func Render(w io.Writer, recap models.WeekRecap, format string) error {
+	var name string
+	switch format {
+	case FormatMarkdown:
+		name = "recap.md.tmpl"
+	case FormatText:
+		name = "recap.txt.tmpl"
+	default:
+		return fmt.Errorf("%w %q", ErrUnknownFormat, format)
+	}
+	if err := templates.ExecuteTemplate(w, name, recap); err != nil {
+		return fmt.Errorf("recap: render %s: %w", format, err)
+	}
+	return nil
+}
+
+func formatRecord(record models.RecordSummary) string {
+	if record.Ties > 0 {
+		return fmt.Sprintf("%d-%d-%d", record.Wins, record.Losses, record.Ties)
+	}
+	return fmt.Sprintf("%d-%d", record.Wins, record.Losses)
+}
+
+func formatMovement(movement int) string {
+	switch {
+	case movement > 0:
+		return fmt.Sprintf("up %d", movement)
+	case movement < 0:
+		return fmt.Sprintf("down %d", -movement)
+	}
+	return "no change"
+}
+
+func formatPoints(points float64) string {
+	return strings.TrimSuffix(strings.TrimRight(fmt.Sprintf("%.1f", points), "0"), ".")
+}
+
+func formatScore(upset models.RecapUpset) string {
+	if upset.HomeScore == nil || upset.AwayScore == nil {
+		return ""
+	}
+	winner, loser := *upset.HomeScore, *upset.AwayScore
+	if loser > winner {
+		winner, loser = loser, winner
+	}
+	return fmt.Sprintf(" %d-%d", winner, loser)
+}
+
+func formatMatchup(highlight models.RecapHighlight) string {
+	if highlight.HomeScore == nil || highlight.AwayScore == nil {
+		return fmt.Sprintf("%s @ %s", highlight.AwayTeam, highlight.HomeTeam)
+	}
+	return fmt.Sprintf("%s %d @ %s %d", highlight.AwayTeam, *highlight.AwayScore, highlight.HomeTeam, *highlight.HomeScore)
+}
+
+func formatTag(tag string) string {
+	switch tag {
+	case models.GameTagBiggestUpset:
+		return "Biggest upset"
+	case models.GameTagClosestGame:
+		return "Closest game"
+	case models.GameTagDecidedWeek:
+		return "Decided the week"
+	}
+	return tag
+}
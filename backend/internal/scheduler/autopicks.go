@@ -0,0 +1,101 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"pickem/backend/internal/config"
+	"pickem/backend/internal/models"
+	"pickem/backend/internal/store"
+	"pickem/backend/internal/webhooks"
+)
+
+const autoPickInterval = 5 * time.Minute
+
+// AutoPickJob fills in missed picks on the current week's games as they
+// lock, following the season's auto-pick rule. Each pick it makes is
+// announced to webhooks as pick.saved, like one entered by hand.
+type AutoPickJob struct {
+	cfg      config.Config
+	store    *store.Store
+	webhooks *webhooks.Dispatcher
+	cancel   context.CancelFunc
+}
+
+func NewAutoPickJob(cfg config.Config, st *store.Store, dispatcher *webhooks.Dispatcher) *AutoPickJob {
+	return &AutoPickJob{cfg: cfg, store: st, webhooks: dispatcher}
+}
+
+// Start begins applying auto picks for the default season.
+func (j *AutoPickJob) Start(ctx context.Context) {
+	if strings.TrimSpace(j.cfg.DefaultSeasonKey) == "" {
+		return
+	}
+
+	loopCtx, cancel := context.WithCancel(ctx)
+	j.cancel = cancel
+
+	go j.loop(loopCtx)
+}
+
+// Stop halts the auto-pick loop.
+func (j *AutoPickJob) Stop() {
+	if j.cancel != nil {
+		j.cancel()
+	}
+}
+
+func (j *AutoPickJob) loop(ctx context.Context) {
+	ticker := time.NewTicker(autoPickInterval)
+	defer ticker.Stop()
+
+	j.run(ctx, time.Now())
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			j.run(ctx, now)
+		}
+	}
+}
+
+func (j *AutoPickJob) run(ctx context.Context, now time.Time) {
+	if j.store == nil {
+		return
+	}
+
+	jobCtx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	season, err := j.store.GetSeasonBySportsKey(jobCtx, j.cfg.DefaultSeasonKey)
+	if err != nil {
+		log.Printf("scheduler: auto picks: lookup season: %v", err)
+		return
+	}
+
+	weekNumber, err := j.store.GetSeasonCurrentWeek(jobCtx, season.ID)
+	if err != nil {
+		log.Printf("scheduler: auto picks: current week: %v", err)
+		return
+	}
+
+	inserted, err := j.store.ApplyAutoPicks(jobCtx, season.ID, weekNumber, now)
+	if err != nil {
+		log.Printf("scheduler: auto picks: week %d: %v", weekNumber, err)
+		return
+	}
+	for _, made := range inserted {
+		j.webhooks.Fire(models.WebhookEventPickSaved, map[string]any{
+			"seasonId":   season.ID,
+			"weekNumber": weekNumber,
+			"gameKey":    made.GameKey,
+			"pick":       made.Pick,
+		})
+	}
+	if len(inserted) > 0 {
+		log.Printf("scheduler: made %d auto picks for week %d", len(inserted), weekNumber)
+	}
+}
@@ -0,0 +1,150 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"pickem/backend/internal/config"
+	"pickem/backend/internal/notify"
+	"pickem/backend/internal/store"
+)
+
+const (
+	reminderInterval = 15 * time.Minute
+	// reminderKind marks missing-pick reminders in the notification log.
+	reminderKind = "missing-picks"
+)
+
+// ReminderJob warns members who still have open games shortly before the
+// current week's first kickoff. Each week is reminded once.
+type ReminderJob struct {
+	cfg      config.Config
+	store    *store.Store
+	notifier *notify.Notifier
+	cancel   context.CancelFunc
+}
+
+func NewReminderJob(cfg config.Config, st *store.Store, notifier *notify.Notifier) *ReminderJob {
+	return &ReminderJob{cfg: cfg, store: st, notifier: notifier}
+}
+
+// Start begins checking for missing picks. It is a no-op when no
+// notification channel is configured.
+func (j *ReminderJob) Start(ctx context.Context) {
+	if !j.notifier.Enabled() || strings.TrimSpace(j.cfg.DefaultSeasonKey) == "" {
+		return
+	}
+
+	loopCtx, cancel := context.WithCancel(ctx)
+	j.cancel = cancel
+
+	go j.loop(loopCtx)
+}
+
+// Stop halts the reminder loop.
+func (j *ReminderJob) Stop() {
+	if j.cancel != nil {
+		j.cancel()
+	}
+}
+
+func (j *ReminderJob) loop(ctx context.Context) {
+	ticker := time.NewTicker(reminderInterval)
+	defer ticker.Stop()
+
+	j.run(ctx, time.Now())
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			j.run(ctx, now)
+		}
+	}
+}
+
+func (j *ReminderJob) run(ctx context.Context, now time.Time) {
+	if j.store == nil {
+		return
+	}
+
+	jobCtx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	season, err := j.store.GetSeasonBySportsKey(jobCtx, j.cfg.DefaultSeasonKey)
+	if err != nil {
+		log.Printf("scheduler: reminders: lookup season: %v", err)
+		return
+	}
+
+	weekNumber, err := j.store.GetSeasonCurrentWeek(jobCtx, season.ID)
+	if err != nil {
+		log.Printf("scheduler: reminders: current week: %v", err)
+		return
+	}
+
+	week, err := j.store.GetWeek(jobCtx, season.ID, weekNumber)
+	if err != nil {
+		log.Printf("scheduler: reminders: load week %d: %v", weekNumber, err)
+		return
+	}
+
+	kickoff, err := j.store.FirstKickoff(jobCtx, week.ID)
+	if err != nil {
+		log.Printf("scheduler: reminders: first kickoff: %v", err)
+		return
+	}
+	// Only remind inside the lead window; once the week has started the
+	// reminder is too late to matter.
+	if kickoff == nil || now.After(*kickoff) || kickoff.Sub(now) > j.cfg.ReminderLead {
+		return
+	}
+
+	sent, err := j.store.NotificationSent(jobCtx, week.ID, reminderKind)
+	if err != nil || sent {
+		if err != nil {
+			log.Printf("scheduler: reminders: check log: %v", err)
+		}
+		return
+	}
+
+	missing, err := j.store.ListMissingPicks(jobCtx, week.ID)
+	if err != nil {
+		log.Printf("scheduler: reminders: missing picks: %v", err)
+		return
+	}
+	if len(missing) == 0 {
+		return
+	}
+
+	msg := notify.Message{
+		Kind:    reminderKind,
+		Subject: fmt.Sprintf("%s picks are due", week.Label),
+	}
+	var body strings.Builder
+	fmt.Fprintf(&body, "%s kicks off %s. Still missing picks:\n", week.Label, kickoff.Local().Format("Mon Jan 2 at 3:04 PM MST"))
+	for _, member := range missing {
+		fmt.Fprintf(&body, "- %s: %d of %d games\n", member.Name, member.Missing, member.OpenGames)
+		msg.Recipients = append(msg.Recipients, notify.Recipient{
+			MemberID: member.MemberID,
+			Name:     member.Name,
+			Email:    j.cfg.MemberEmails[member.Name],
+		})
+	}
+	msg.Body = body.String()
+
+	if err := j.notifier.Send(jobCtx, msg); err != nil {
+		log.Printf("scheduler: reminders: send: %v", err)
+		return
+	}
+
+	if err := j.store.RecordNotification(jobCtx, week.ID, reminderKind, len(missing)); err != nil {
+		log.Printf("scheduler: reminders: record: %v", err)
+		return
+	}
+
+	log.Printf("scheduler: reminded %d members about %s", len(missing), week.Label)
+}
@@ -0,0 +1,507 @@
+package seasoncsv
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"pickem/backend/internal/models"
+	"pickem/backend/internal/nfl"
+)
+
+// Row types in the first column. Game rows carry the member × game pick
+// matrix; tie-breaker rows put each member's points in their column; the
+// winner rows name a member in the winner column.
+const (
+	RowGame        = "game"
+	RowTieBreaker  = "tie-breaker"
+	RowWeekWinner  = "week-winner"
+	RowSeasonTitle = "season-title"
+)
+
+// columns are the fixed leading columns; one column per member follows.
+var columns = []string{
+	"type", "week", "game_key", "kickoff", "away", "home",
+	"away_score", "home_score", "status", "winner",
+}
+
+// ErrInvalid is wrapped by *ValidationError.
+var ErrInvalid = errors.New("seasoncsv: invalid sheet")
+
+// Game is one game row. Winner and Picks hold sides ("home", "away", or
+// "tie" for the winner); picks are keyed by member name.
+type Game struct {
+	Week      int
+	GameKey   string
+	Kickoff   *time.Time
+	Away      string
+	Home      string
+	AwayScore *int
+	HomeScore *int
+	Status    string
+	Winner    string
+	Picks     map[string]string
+}
+
+// Sheet is a whole season in spreadsheet form, keyed by member name so it
+// reads the same in any database.
+type Sheet struct {
+	Members     []string
+	Games       []Game
+	TieBreakers map[int]map[string]int
+	WeekWinners map[int]string
+	Champion    string
+}
+
+// Problem is one thing wrong with an imported sheet.
+type Problem struct {
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+// ValidationError lists every problem found, so a spreadsheet can be fixed
+// in one pass instead of one error at a time.
+type ValidationError struct {
+	Problems []Problem
+}
+
+func (e *ValidationError) Error() string {
+	if len(e.Problems) == 1 {
+		return fmt.Sprintf("seasoncsv: line %d: %s", e.Problems[0].Line, e.Problems[0].Message)
+	}
+	return fmt.Sprintf("seasoncsv: %d problems, first on line %d: %s", len(e.Problems), e.Problems[0].Line, e.Problems[0].Message)
+}
+
+func (e *ValidationError) Unwrap() error { return ErrInvalid }
+
+// Write renders the sheet as CSV: game rows by week, then each week's
+// tie-breakers and winner, then the season title. Picks and winners are
+// written as team codes so the file reads naturally in a spreadsheet.
+func Write(w io.Writer, sheet Sheet) error {
+	out := csv.NewWriter(w)
+	if err := out.Write(append(append([]string{}, columns...), sheet.Members...)); err != nil {
+		return err
+	}
+
+	weeks := map[int]bool{}
+	for _, game := range sheet.Games {
+		weeks[game.Week] = true
+	}
+	for week := range sheet.TieBreakers {
+		weeks[week] = true
+	}
+	for week := range sheet.WeekWinners {
+		weeks[week] = true
+	}
+	ordered := make([]int, 0, len(weeks))
+	for week := range weeks {
+		ordered = append(ordered, week)
+	}
+	sort.Ints(ordered)
+
+	blank := func() []string { return make([]string, len(columns)+len(sheet.Members)) }
+	for _, week := range ordered {
+		for _, game := range sheet.Games {
+			if game.Week != week {
+				continue
+			}
+			row := blank()
+			row[0] = RowGame
+			row[1] = strconv.Itoa(week)
+			row[2] = game.GameKey
+			if game.Kickoff != nil {
+				row[3] = game.Kickoff.UTC().Format(time.RFC3339)
+			}
+			row[4] = game.Away
+			row[5] = game.Home
+			row[6] = formatScore(game.AwayScore)
+			row[7] = formatScore(game.HomeScore)
+			row[8] = game.Status
+			row[9] = sideCode(game, game.Winner)
+			for i, member := range sheet.Members {
+				row[len(columns)+i] = sideCode(game, game.Picks[member])
+			}
+			if err := out.Write(row); err != nil {
+				return err
+			}
+		}
+
+		if points, ok := sheet.TieBreakers[week]; ok && len(points) > 0 {
+			row := blank()
+			row[0] = RowTieBreaker
+			row[1] = strconv.Itoa(week)
+			for i, member := range sheet.Members {
+				if value, ok := points[member]; ok {
+					row[len(columns)+i] = strconv.Itoa(value)
+				}
+			}
+			if err := out.Write(row); err != nil {
+				return err
+			}
+		}
+
+		if winner := sheet.WeekWinners[week]; winner != "" {
+			row := blank()
+			row[0] = RowWeekWinner
+			row[1] = strconv.Itoa(week)
+			row[9] = winner
+			if err := out.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+
+	if sheet.Champion != "" {
+		row := blank()
+		row[0] = RowSeasonTitle
+		row[9] = sheet.Champion
+		if err := out.Write(row); err != nil {
+			return err
+		}
+	}
+
+	out.Flush()
+	return out.Error()
+}
+
+// Read parses and validates a sheet. Team codes, nicknames and old
+// abbreviations are accepted wherever a team is expected, as are "home",
+// "away" and "tie". Game keys may be left blank for games that never had
+// one. Every problem is collected into a *ValidationError.
+func Read(r io.Reader) (*Sheet, error) {
+	in := csv.NewReader(r)
+	in.FieldsPerRecord = -1
+	in.TrimLeadingSpace = true
+
+	records, err := in.ReadAll()
+	if err != nil {
+		return nil, &ValidationError{Problems: []Problem{{Line: lineOf(err), Message: err.Error()}}}
+	}
+	if len(records) == 0 {
+		return nil, &ValidationError{Problems: []Problem{{Line: 1, Message: "the file is empty"}}}
+	}
+
+	v := &validator{}
+	header := records[0]
+	if len(header) < len(columns) {
+		v.add(1, "the header needs the columns %s followed by one column per member", strings.Join(columns, ","))
+		return nil, v.err()
+	}
+	for i, name := range columns {
+		if !strings.EqualFold(strings.TrimSpace(header[i]), name) {
+			v.add(1, "column %d should be %q, not %q", i+1, name, header[i])
+		}
+	}
+
+	sheet := &Sheet{
+		TieBreakers: map[int]map[string]int{},
+		WeekWinners: map[int]string{},
+	}
+	memberSet := map[string]bool{}
+	for _, name := range header[len(columns):] {
+		name = strings.TrimSpace(name)
+		switch {
+		case name == "":
+			v.add(1, "a member column has no name")
+		case memberSet[strings.ToLower(name)]:
+			v.add(1, "member %q appears twice", name)
+		default:
+			memberSet[strings.ToLower(name)] = true
+			sheet.Members = append(sheet.Members, name)
+		}
+	}
+	if len(v.problems) > 0 {
+		return nil, v.err()
+	}
+
+	gameKeys := map[string]int{}
+	matchups := map[string]int{}
+	for idx, record := range records[1:] {
+		line := idx + 2
+		if blankRecord(record) {
+			continue
+		}
+		cell := func(i int) string {
+			if i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		kind := strings.ToLower(cell(0))
+		switch kind {
+		case RowGame, RowTieBreaker, RowWeekWinner, RowSeasonTitle:
+		default:
+			v.add(line, "unknown row type %q (expected %s, %s, %s or %s)", cell(0), RowGame, RowTieBreaker, RowWeekWinner, RowSeasonTitle)
+			continue
+		}
+
+		week := 0
+		if kind != RowSeasonTitle {
+			parsed, err := strconv.Atoi(cell(1))
+			if err != nil || parsed < 1 {
+				v.add(line, "week %q is not a positive number", cell(1))
+				continue
+			}
+			week = parsed
+		}
+
+		switch kind {
+		case RowGame:
+			game, ok := v.game(line, week, cell, sheet.Members)
+			if !ok {
+				continue
+			}
+			if first, dup := gameKeys[game.GameKey]; dup && game.GameKey != "" {
+				v.add(line, "game %s already appears on line %d", game.GameKey, first)
+				continue
+			}
+			matchup := fmt.Sprintf("%d:%s@%s", week, game.Away, game.Home)
+			if first, dup := matchups[matchup]; dup {
+				v.add(line, "%s at %s in week %d already appears on line %d", game.Away, game.Home, week, first)
+				continue
+			}
+			gameKeys[game.GameKey] = line
+			matchups[matchup] = line
+			sheet.Games = append(sheet.Games, game)
+
+		case RowTieBreaker:
+			if _, dup := sheet.TieBreakers[week]; dup {
+				v.add(line, "week %d already has a tie-breaker row", week)
+				continue
+			}
+			points := map[string]int{}
+			for i, member := range sheet.Members {
+				value := cell(len(columns) + i)
+				if value == "" {
+					continue
+				}
+				parsed, err := strconv.Atoi(value)
+				if err != nil || parsed < 0 {
+					v.add(line, "%s's tie-breaker %q is not a number", member, value)
+					continue
+				}
+				points[member] = parsed
+			}
+			sheet.TieBreakers[week] = points
+
+		case RowWeekWinner, RowSeasonTitle:
+			winner, ok := memberNamed(sheet.Members, cell(9))
+			if !ok {
+				v.add(line, "winner %q is not one of the member columns", cell(9))
+				continue
+			}
+			if kind == RowSeasonTitle {
+				if sheet.Champion != "" {
+					v.add(line, "the season title appears twice")
+					continue
+				}
+				sheet.Champion = winner
+				continue
+			}
+			if _, dup := sheet.WeekWinners[week]; dup {
+				v.add(line, "week %d already has a winner", week)
+				continue
+			}
+			sheet.WeekWinners[week] = winner
+		}
+	}
+
+	if len(sheet.Games) == 0 && len(v.problems) == 0 {
+		v.add(1, "the sheet has no game rows")
+	}
+	if len(v.problems) > 0 {
+		return nil, v.err()
+	}
+	return sheet, nil
+}
+
+type validator struct {
+	problems []Problem
+}
+
+func (v *validator) add(line int, format string, args ...any) {
+	v.problems = append(v.problems, Problem{Line: line, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) err() error {
+	return &ValidationError{Problems: v.problems}
+}
+
+func (v *validator) game(line, week int, cell func(int) string, members []string) (Game, bool) {
+	before := len(v.problems)
+	game := Game{
+		Week:    week,
+		GameKey: cell(2),
+		Status:  strings.ToLower(cell(8)),
+		Picks:   map[string]string{},
+	}
+
+	if value := cell(3); value != "" {
+		kickoff, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			v.add(line, "kickoff %q is not an RFC 3339 time", value)
+		} else {
+			game.Kickoff = &kickoff
+		}
+	}
+
+	game.Away = teamCode(cell(4))
+	game.Home = teamCode(cell(5))
+	if game.Away == "" {
+		v.add(line, "away team %q does not name one NFL team", cell(4))
+	}
+	if game.Home == "" {
+		v.add(line, "home team %q does not name one NFL team", cell(5))
+	}
+	if game.Away != "" && game.Away == game.Home {
+		v.add(line, "%s cannot play itself", game.Home)
+	}
+
+	game.AwayScore = v.score(line, "away", cell(6))
+	game.HomeScore = v.score(line, "home", cell(7))
+	if (game.AwayScore == nil) != (game.HomeScore == nil) {
+		v.add(line, "give both scores or neither")
+	}
+
+	if game.Status == "" {
+		game.Status = models.GameStatusScheduled
+		if game.HomeScore != nil || cell(9) != "" {
+			game.Status = models.GameStatusFinal
+		}
+	}
+	switch game.Status {
+	case models.GameStatusScheduled, models.GameStatusInProgress, models.GameStatusFinal,
+		models.GameStatusPostponed, models.GameStatusSuspended, models.GameStatusCanceled,
+		models.GameStatusForfeit:
+	default:
+		v.add(line, "status %q is not a game status", cell(8))
+	}
+
+	if value := cell(9); value != "" {
+		winner, ok := side(game, value, true)
+		if !ok {
+			v.add(line, "winner %q is neither team nor a tie", value)
+		}
+		game.Winner = winner
+	}
+	if game.Winner == "" && game.HomeScore != nil && game.AwayScore != nil &&
+		(game.Status == models.GameStatusFinal || game.Status == models.GameStatusForfeit) {
+		switch {
+		case *game.HomeScore > *game.AwayScore:
+			game.Winner = "home"
+		case *game.AwayScore > *game.HomeScore:
+			game.Winner = "away"
+		default:
+			game.Winner = models.GameWinnerTie
+		}
+	}
+	if game.Winner != "" && game.Status != models.GameStatusFinal && game.Status != models.GameStatusForfeit {
+		v.add(line, "only final or forfeit games can have a winner")
+	}
+
+	for i, member := range members {
+		value := cell(len(columns) + i)
+		if value == "" {
+			continue
+		}
+		picked, ok := side(game, value, false)
+		if !ok {
+			v.add(line, "%s's pick %q is neither team in the game", member, value)
+			continue
+		}
+		game.Picks[member] = picked
+	}
+
+	return game, len(v.problems) == before
+}
+
+func (v *validator) score(line int, label, value string) *int {
+	if value == "" {
+		return nil
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed < 0 {
+		v.add(line, "%s score %q is not a number", label, value)
+		return nil
+	}
+	return &parsed
+}
+
+// teamCode resolves anything nfl.MatchExact recognises to a single code.
+func teamCode(value string) string {
+	teams := nfl.MatchExact(value)
+	if len(teams) != 1 {
+		return ""
+	}
+	return teams[0].Code
+}
+
+// side turns a cell naming a team, "home" or "away" (or "tie" when allowed)
+// into the stored side.
+func side(game Game, value string, allowTie bool) (string, bool) {
+	lower := strings.ToLower(value)
+	switch lower {
+	case "home", "away":
+		return lower, true
+	case models.GameWinnerTie:
+		return lower, allowTie
+	}
+	switch teamCode(value) {
+	case "":
+		return "", false
+	case game.Home:
+		return "home", true
+	case game.Away:
+		return "away", true
+	}
+	return "", false
+}
+
+func sideCode(game Game, side string) string {
+	switch side {
+	case "home":
+		return game.Home
+	case "away":
+		return game.Away
+	}
+	return side
+}
+
+func memberNamed(members []string, name string) (string, bool) {
+	for _, member := range members {
+		if strings.EqualFold(member, name) {
+			return member, true
+		}
+	}
+	return "", false
+}
+
+func formatScore(score *int) string {
+	if score == nil {
+		return ""
+	}
+	return strconv.Itoa(*score)
+}
+
+func blankRecord(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func lineOf(err error) int {
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return parseErr.Line
+	}
+	return 1
+}
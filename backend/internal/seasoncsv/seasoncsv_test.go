@@ -0,0 +1,209 @@
+package seasoncsv
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"pickem/backend/internal/models"
+)
+
+func TestWriteReadRoundTrip(t *testing.T) {
+	kickoff := time.Date(2024, 9, 8, 17, 0, 0, 0, time.UTC)
+	homeScore, awayScore := 27, 20
+	tied := 17
+	sheet := Sheet{
+		Members: []string{"Alex", "Sam"},
+		Games: []Game{
+			{
+				Week: 1, GameKey: "2024-W1-BUF@KC", Kickoff: &kickoff, Away: "BUF", Home: "KC",
+				AwayScore: &awayScore, HomeScore: &homeScore, Status: models.GameStatusFinal, Winner: "home",
+				Picks: map[string]string{"Alex": "home", "Sam": "away"},
+			},
+			{
+				Week: 1, GameKey: "2024-W1-DAL@PHI", Away: "DAL", Home: "PHI",
+				AwayScore: &tied, HomeScore: &tied, Status: models.GameStatusFinal, Winner: models.GameWinnerTie,
+				Picks: map[string]string{"Alex": "away"},
+			},
+			{
+				Week: 2, GameKey: "2024-W2-KC@BUF", Away: "KC", Home: "BUF",
+				Status: models.GameStatusScheduled,
+				Picks:  map[string]string{"Sam": "home"},
+			},
+		},
+		TieBreakers: map[int]map[string]int{1: {"Alex": 41, "Sam": 38}},
+		WeekWinners: map[int]string{1: "Alex"},
+		Champion:    "Sam",
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, sheet); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	got, err := Read(&buf)
+	if err != nil {
+		t.Fatalf("Read: %v\n%s", err, buf.String())
+	}
+	if !reflect.DeepEqual(*got, sheet) {
+		t.Errorf("round trip changed the sheet\n got %+v\nwant %+v", *got, sheet)
+	}
+}
+
+func TestReadReportsProblems(t *testing.T) {
+	const header = "type,week,game_key,kickoff,away,home,away_score,home_score,status,winner,Alex,Sam\n"
+	tests := []struct {
+		name     string
+		csv      string
+		wantLine int
+		want     string
+	}{
+		{
+			name:     "empty file",
+			csv:      "",
+			wantLine: 1,
+			want:     "the file is empty",
+		},
+		{
+			name:     "short header",
+			csv:      "type,week,game_key\n",
+			wantLine: 1,
+			want:     "the header needs the columns",
+		},
+		{
+			name:     "misnamed column",
+			csv:      "type,week,key,kickoff,away,home,away_score,home_score,status,winner,Alex\n",
+			wantLine: 1,
+			want:     `column 3 should be "game_key"`,
+		},
+		{
+			name:     "member column twice",
+			csv:      "type,week,game_key,kickoff,away,home,away_score,home_score,status,winner,Alex,alex\n",
+			wantLine: 1,
+			want:     `member "alex" appears twice`,
+		},
+		{
+			name:     "unknown row type",
+			csv:      header + "bye,1,,,,,,,,,,\n",
+			wantLine: 2,
+			want:     `unknown row type "bye"`,
+		},
+		{
+			name:     "bad week",
+			csv:      header + "game,first,,,BUF,KC,,,,,,\n",
+			wantLine: 2,
+			want:     `week "first" is not a positive number`,
+		},
+		{
+			name:     "unknown team",
+			csv:      header + "game,1,,,BUF,Springfield,,,,,,\n",
+			wantLine: 2,
+			want:     `home team "Springfield" does not name one NFL team`,
+		},
+		{
+			name:     "winner not in the game",
+			csv:      header + "game,1,,,BUF,KC,20,27,final,DAL,,\n",
+			wantLine: 2,
+			want:     `winner "DAL" is neither team nor a tie`,
+		},
+		{
+			name:     "winner on an unfinished game",
+			csv:      header + "game,1,,,BUF,KC,,,scheduled,KC,,\n",
+			wantLine: 2,
+			want:     "only final or forfeit games can have a winner",
+		},
+		{
+			name:     "pick for a team not playing",
+			csv:      header + "game,1,,,BUF,KC,,,,,DAL,\n",
+			wantLine: 2,
+			want:     `Alex's pick "DAL" is neither team in the game`,
+		},
+		{
+			name:     "pick for a tie",
+			csv:      header + "game,1,,,BUF,KC,,,,,,tie\n",
+			wantLine: 2,
+			want:     `Sam's pick "tie" is neither team in the game`,
+		},
+		{
+			name:     "one score only",
+			csv:      header + "game,1,,,BUF,KC,20,,,,,\n",
+			wantLine: 2,
+			want:     "give both scores or neither",
+		},
+		{
+			name:     "week winner not a member column",
+			csv:      header + "game,1,,,BUF,KC,,,,,,\nweek-winner,1,,,,,,,,Jordan,,\n",
+			wantLine: 3,
+			want:     `winner "Jordan" is not one of the member columns`,
+		},
+		{
+			name:     "duplicate week winner",
+			csv:      header + "game,1,,,BUF,KC,,,,,,\nweek-winner,1,,,,,,,,Alex,,\nweek-winner,1,,,,,,,,sam,,\n",
+			wantLine: 4,
+			want:     "week 1 already has a winner",
+		},
+		{
+			name:     "season title twice",
+			csv:      header + "game,1,,,BUF,KC,,,,,,\nseason-title,,,,,,,,,Alex,,\nseason-title,,,,,,,,,Sam,,\n",
+			wantLine: 4,
+			want:     "the season title appears twice",
+		},
+		{
+			name:     "repeated matchup",
+			csv:      header + "game,1,,,BUF,KC,,,,,,\ngame,1,,,Bills,Chiefs,,,,,,\n",
+			wantLine: 3,
+			want:     "BUF at KC in week 1 already appears on line 2",
+		},
+		{
+			name:     "bad tie-breaker",
+			csv:      header + "game,1,,,BUF,KC,,,,,,\ntie-breaker,1,,,,,,,,,forty,\n",
+			wantLine: 3,
+			want:     `Alex's tie-breaker "forty" is not a number`,
+		},
+		{
+			name:     "no games",
+			csv:      header + "season-title,,,,,,,,,Alex,,\n",
+			wantLine: 1,
+			want:     "the sheet has no game rows",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Read(strings.NewReader(tt.csv))
+			if !errors.Is(err, ErrInvalid) {
+				t.Fatalf("Read() error = %v, want ErrInvalid", err)
+			}
+			var invalid *ValidationError
+			if !errors.As(err, &invalid) {
+				t.Fatalf("Read() error = %T, want *ValidationError", err)
+			}
+			for _, problem := range invalid.Problems {
+				if problem.Line == tt.wantLine && strings.Contains(problem.Message, tt.want) {
+					return
+				}
+			}
+			t.Errorf("no problem on line %d containing %q; got %+v", tt.wantLine, tt.want, invalid.Problems)
+		})
+	}
+}
+
+func TestReadCollectsEveryProblem(t *testing.T) {
+	const sheet = "type,week,game_key,kickoff,away,home,away_score,home_score,status,winner,Alex\n" +
+		"game,1,,,BUF,KC,,,,,DAL\n" +
+		"game,0,,,BUF,KC,,,,,\n" +
+		"game,2,,yesterday,BUF,KC,,,,,\n"
+	_, err := Read(strings.NewReader(sheet))
+	var invalid *ValidationError
+	if !errors.As(err, &invalid) {
+		t.Fatalf("Read() error = %v, want *ValidationError", err)
+	}
+	lines := []int{}
+	for _, problem := range invalid.Problems {
+		lines = append(lines, problem.Line)
+	}
+	if !reflect.DeepEqual(lines, []int{2, 3, 4}) {
+		t.Errorf("problem lines = %v, want [2 3 4]: %+v", lines, invalid.Problems)
+	}
+}
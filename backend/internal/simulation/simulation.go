@@ -0,0 +1,272 @@
+package simulation
+
+import (
+	"math"
+	"math/rand"
+
+	"pickem/backend/internal/models"
+	"pickem/backend/internal/standings"
+)
+
+const (
+	// DefaultIterations balances stable percentages against page load time.
+	DefaultIterations = 10000
+	// MaxIterations caps what a caller can ask for.
+	MaxIterations = 200000
+
+	// defaultGameTotal and gameTotalStdDev describe an NFL game's combined
+	// score when there is no over/under to center on.
+	defaultGameTotal = 44.0
+	gameTotalStdDev  = 13.5
+
+	// tieProbability is roughly how often an NFL game ends level after
+	// overtime: about one game in three hundred.
+	tieProbability = 0.003
+)
+
+// Pick is one member's side on an undecided game.
+type Pick struct {
+	MemberID string
+	Side     string
+}
+
+// Game is an undecided game with the chance its home team wins.
+type Game struct {
+	GameKey            string
+	HomeWinProbability float64
+	Picks              []Pick
+}
+
+// TieBreaker is the week's tie-breaker: members guess the combined score of
+// the week's last game and the closest guess takes a tied week.
+type TieBreaker struct {
+	Guesses map[string]int
+	// ActualTotal is set once the tie-breaker game is final.
+	ActualTotal *int
+	// ExpectedTotal centers the simulated total (the over/under when known).
+	ExpectedTotal float64
+}
+
+// Week is the state of the week being simulated.
+type Week struct {
+	MemberIDs []string
+	// Current holds the points already earned this week.
+	Current   map[string]float64
+	Remaining []Game
+	// TiePoints is what every pick on a tied game earns under the season's
+	// tie scoring.
+	TiePoints  float64
+	TieBreaker TieBreaker
+	// DeclaredWinner settles the week without simulating it.
+	DeclaredWinner string
+}
+
+// Season is everything outside the simulated week.
+type Season struct {
+	Rule string
+	// WeeksWon counts declared weeks other than the simulated one.
+	WeeksWon map[string]int
+	// Points and Graded are season totals including the simulated week's
+	// graded picks so far.
+	Points map[string]float64
+	Graded map[string]int
+	// FutureWeeks and FutureGames describe the rest of the season after the
+	// simulated week. With no picks to go on, every member is equally likely
+	// to win a future week and to get a future pick right.
+	FutureWeeks int
+	FutureGames int
+}
+
+// Result holds each member's chance of winning the week and the season.
+type Result struct {
+	Iterations int
+	Week       map[string]float64
+	Season     map[string]float64
+}
+
+// Run samples the remaining outcomes iterations times.
+func Run(week Week, season Season, iterations int, rng *rand.Rand) Result {
+	if iterations <= 0 {
+		iterations = DefaultIterations
+	}
+	if iterations > MaxIterations {
+		iterations = MaxIterations
+	}
+
+	result := Result{
+		Iterations: iterations,
+		Week:       map[string]float64{},
+		Season:     map[string]float64{},
+	}
+	members := week.MemberIDs
+	if len(members) == 0 {
+		return result
+	}
+	for _, id := range members {
+		result.Week[id] = 0
+		result.Season[id] = 0
+	}
+
+	weekWins := map[string]int{}
+	seasonWins := map[string]int{}
+	scores := make(map[string]float64, len(members))
+	picked := make(map[string]int, len(members))
+	weeksWon := make(map[string]int, len(members))
+	metric := make(map[string]float64, len(members))
+
+	for i := 0; i < iterations; i++ {
+		for _, id := range members {
+			scores[id] = week.Current[id]
+			picked[id] = 0
+		}
+		for _, game := range week.Remaining {
+			side := sampleOutcome(game.HomeWinProbability, rng)
+			for _, pick := range game.Picks {
+				picked[pick.MemberID]++
+				switch {
+				case side == models.GameWinnerTie:
+					scores[pick.MemberID] += week.TiePoints
+				case pick.Side == side:
+					scores[pick.MemberID]++
+				}
+			}
+		}
+
+		weekWinner := week.DeclaredWinner
+		if weekWinner == "" {
+			weekWinner = pickWeekWinner(members, scores, week.TieBreaker, rng)
+			weekWins[weekWinner]++
+		}
+
+		for _, id := range members {
+			weeksWon[id] = season.WeeksWon[id]
+		}
+		if week.DeclaredWinner == "" {
+			weeksWon[weekWinner]++
+		}
+		for w := 0; w < season.FutureWeeks; w++ {
+			weeksWon[members[rng.Intn(len(members))]]++
+		}
+
+		for _, id := range members {
+			points := season.Points[id] + scores[id] - week.Current[id]
+			graded := season.Graded[id] + picked[id]
+			if season.FutureGames > 0 {
+				points += sampleCoinFlips(season.FutureGames, rng)
+				graded += season.FutureGames
+			}
+			switch season.Rule {
+			case standings.RuleTotalCorrect:
+				metric[id] = points
+			case standings.RuleWinPct:
+				if graded > 0 {
+					metric[id] = points / float64(graded)
+				} else {
+					metric[id] = 0
+				}
+			default:
+				metric[id] = float64(weeksWon[id])
+			}
+		}
+		seasonWins[pickLeader(members, metric, rng)]++
+	}
+
+	if week.DeclaredWinner != "" {
+		if _, ok := result.Week[week.DeclaredWinner]; ok {
+			result.Week[week.DeclaredWinner] = 1
+		}
+	} else {
+		for id, wins := range weekWins {
+			result.Week[id] = float64(wins) / float64(iterations)
+		}
+	}
+	for id, wins := range seasonWins {
+		result.Season[id] = float64(wins) / float64(iterations)
+	}
+	return result
+}
+
+// sampleOutcome draws "home", "away" or models.GameWinnerTie. Ties take their share evenly
+// from both sides so the odds-based home probability keeps its ratio.
+func sampleOutcome(homeWinProbability float64, rng *rand.Rand) string {
+	draw := rng.Float64()
+	if draw < tieProbability {
+		return models.GameWinnerTie
+	}
+	if (draw-tieProbability)/(1-tieProbability) < homeWinProbability {
+		return "home"
+	}
+	return "away"
+}
+
+// pickWeekWinner applies the tie-breaker to the top scorers: the guess
+// closest to the game total wins, members without a guess lose the tie, and
+// anything still level is settled by a coin flip.
+func pickWeekWinner(members []string, scores map[string]float64, tieBreaker TieBreaker, rng *rand.Rand) string {
+	leaders := topMembers(members, scores)
+	if len(leaders) == 1 {
+		return leaders[0]
+	}
+
+	total := 0.0
+	if tieBreaker.ActualTotal != nil {
+		total = float64(*tieBreaker.ActualTotal)
+	} else {
+		expected := tieBreaker.ExpectedTotal
+		if expected <= 0 {
+			expected = defaultGameTotal
+		}
+		total = math.Max(0, math.Round(expected+rng.NormFloat64()*gameTotalStdDev))
+	}
+
+	distance := map[string]float64{}
+	for _, id := range leaders {
+		guess, ok := tieBreaker.Guesses[id]
+		if !ok {
+			distance[id] = math.Inf(-1)
+			continue
+		}
+		distance[id] = -math.Abs(float64(guess) - total)
+	}
+	return pickLeader(leaders, distance, rng)
+}
+
+// pickLeader returns the member with the highest value, choosing uniformly
+// among ties.
+func pickLeader(members []string, values map[string]float64, rng *rand.Rand) string {
+	leaders := topMembers(members, values)
+	return leaders[rng.Intn(len(leaders))]
+}
+
+func topMembers(members []string, values map[string]float64) []string {
+	best := math.Inf(-1)
+	leaders := []string{}
+	for _, id := range members {
+		value := values[id]
+		switch {
+		case value > best:
+			best = value
+			leaders = append(leaders[:0], id)
+		case value == best:
+			leaders = append(leaders, id)
+		}
+	}
+	return leaders
+}
+
+// sampleCoinFlips draws the number of correct picks out of n fair games,
+// using the normal approximation once n is large enough for it to hold.
+func sampleCoinFlips(n int, rng *rand.Rand) float64 {
+	if n < 30 {
+		hits := 0
+		for i := 0; i < n; i++ {
+			if rng.Intn(2) == 0 {
+				hits++
+			}
+		}
+		return float64(hits)
+	}
+	mean := float64(n) / 2
+	sample := math.Round(mean + rng.NormFloat64()*math.Sqrt(float64(n))/2)
+	return math.Min(float64(n), math.Max(0, sample))
+}
@@ -0,0 +1,50 @@
+package simulation
+
+import (
+	"math/rand"
+	"testing"
+
+	"pickem/backend/internal/models"
+)
+
+func TestSampleOutcomeDrawsTies(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	counts := map[string]int{}
+	const draws = 200000
+	for i := 0; i < draws; i++ {
+		counts[sampleOutcome(0.75, rng)]++
+	}
+	ties := float64(counts[models.GameWinnerTie]) / draws
+	if ties < tieProbability/2 || ties > tieProbability*2 {
+		t.Errorf("tie rate = %.4f, want about %.4f", ties, tieProbability)
+	}
+	home := float64(counts["home"]) / float64(counts["home"]+counts["away"])
+	if home < 0.74 || home > 0.76 {
+		t.Errorf("home share of decided games = %.3f, want about 0.75", home)
+	}
+}
+
+func TestRunScoresTiesWithTiePoints(t *testing.T) {
+	// The home side always wins unless the game ends level, so a, who trails
+	// by half a point and picked the away side, can only win the week on a
+	// tie worth more than half a point.
+	week := Week{
+		MemberIDs: []string{"a", "b"},
+		Current:   map[string]float64{"a": 0, "b": 0.5},
+		Remaining: []Game{{GameKey: "g1", HomeWinProbability: 1, Picks: []Pick{
+			{MemberID: "a", Side: "away"},
+		}}},
+	}
+	const iterations = 200000
+
+	week.TiePoints = 0
+	if got := Run(week, Season{}, iterations, rand.New(rand.NewSource(3))).Week["a"]; got != 0 {
+		t.Errorf("ties worth nothing: a wins %.4f of weeks, want 0", got)
+	}
+
+	week.TiePoints = 1
+	got := Run(week, Season{}, iterations, rand.New(rand.NewSource(3))).Week["a"]
+	if got < tieProbability/2 || got > tieProbability*2 {
+		t.Errorf("ties worth a point: a wins %.4f of weeks, want about %.4f", got, tieProbability)
+	}
+}
@@ -0,0 +1,162 @@
+package standings
+
+import (
+	"sort"
+
+	"pickem/backend/internal/models"
+)
+
+// Ranking rules for the season leaderboard.
+const (
+	RuleWeeksWon     = "weeks-won"
+	RuleTotalCorrect = "total-correct"
+	RuleWinPct       = "win-pct"
+)
+
+// ValidRule reports whether rule is one of the supported ranking rules.
+func ValidRule(rule string) bool {
+	switch rule {
+	case RuleWeeksWon, RuleTotalCorrect, RuleWinPct:
+		return true
+	}
+	return false
+}
+
+// MemberWeeks is one member's graded record for every week of a season.
+// Records must already carry points for the season's tie scoring.
+type MemberWeeks struct {
+	MemberID string
+	Name     string
+	Records  map[int]models.RecordSummary
+	WeeksWon map[int]bool
+}
+
+// Build ranks members through the given week. Members level on the rule's
+// metric share a rank (1, 1, 3). Movement compares against the standings one
+// week earlier; members move up when the number is positive.
+func Build(members []MemberWeeks, weeks []int, rule string, throughWeek int) models.Standings {
+	if !ValidRule(rule) {
+		rule = RuleWeeksWon
+	}
+
+	included := []int{}
+	for _, week := range weeks {
+		if week <= throughWeek {
+			included = append(included, week)
+		}
+	}
+
+	current := rank(members, included, rule, throughWeek)
+	if throughWeek > 1 {
+		previous := rank(members, included, rule, throughWeek-1)
+		previousRanks := map[string]int{}
+		for _, entry := range previous {
+			previousRanks[entry.MemberID] = entry.Rank
+		}
+		for i := range current {
+			if prev, ok := previousRanks[current[i].MemberID]; ok {
+				current[i].PreviousRank = &prev
+				current[i].Movement = prev - current[i].Rank
+			}
+		}
+	}
+
+	return models.Standings{
+		Rule:        rule,
+		ThroughWeek: throughWeek,
+		Weeks:       included,
+		Entries:     current,
+	}
+}
+
+func rank(members []MemberWeeks, weeks []int, rule string, throughWeek int) []models.StandingsEntry {
+	entries := make([]models.StandingsEntry, 0, len(members))
+	for _, member := range members {
+		entry := models.StandingsEntry{
+			MemberID: member.MemberID,
+			Name:     member.Name,
+			Weeks:    []models.WeeklyRecord{},
+		}
+		for _, week := range weeks {
+			if week > throughWeek {
+				continue
+			}
+			record := member.Records[week]
+			won := member.WeeksWon[week]
+			entry.Record.Wins += record.Wins
+			entry.Record.Losses += record.Losses
+			entry.Record.Ties += record.Ties
+			entry.Record.Points += record.Points
+			if won {
+				entry.WeeksWon++
+			}
+			entry.Weeks = append(entry.Weeks, models.WeeklyRecord{
+				WeekNumber: week,
+				Record:     record,
+				WonWeek:    won,
+			})
+		}
+		entry.WinPct = WinPct(entry.Record)
+		entries = append(entries, entry)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := metric(entries[i], rule), metric(entries[j], rule)
+		if a != b {
+			return a > b
+		}
+		return entries[i].Name < entries[j].Name
+	})
+
+	for i := range entries {
+		if i > 0 && metric(entries[i], rule) == metric(entries[i-1], rule) {
+			entries[i].Rank = entries[i-1].Rank
+			entries[i].Tied = true
+			entries[i-1].Tied = true
+		} else {
+			entries[i].Rank = i + 1
+		}
+	}
+
+	if len(entries) > 0 {
+		leader := entries[0]
+		for i := range entries {
+			entries[i].GamesBehind = gamesBehind(leader, entries[i], rule)
+		}
+	}
+
+	return entries
+}
+
+// WinPct is the share of graded picks earned, with pushes credited at the
+// season's tie scoring.
+func WinPct(record models.RecordSummary) float64 {
+	games := record.Wins + record.Losses + record.Ties
+	if games == 0 {
+		return 0
+	}
+	return record.Points / float64(games)
+}
+
+func metric(entry models.StandingsEntry, rule string) float64 {
+	switch rule {
+	case RuleTotalCorrect:
+		return entry.Record.Points
+	case RuleWinPct:
+		return entry.WinPct
+	}
+	return float64(entry.WeeksWon)
+}
+
+func gamesBehind(leader, entry models.StandingsEntry, rule string) float64 {
+	switch rule {
+	case RuleTotalCorrect:
+		return leader.Record.Points - entry.Record.Points
+	case RuleWinPct:
+		// Classic baseball games behind, treating uncredited picks as losses.
+		leaderMisses := float64(leader.Record.Wins+leader.Record.Losses+leader.Record.Ties) - leader.Record.Points
+		misses := float64(entry.Record.Wins+entry.Record.Losses+entry.Record.Ties) - entry.Record.Points
+		return ((leader.Record.Points - entry.Record.Points) + (misses - leaderMisses)) / 2
+	}
+	return float64(leader.WeeksWon - entry.WeeksWon)
+}
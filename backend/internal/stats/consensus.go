@@ -0,0 +1,82 @@
+package stats
+
+import "pickem/backend/internal/models"
+
+// GameConsensus tallies the family's picks on a game. A lone wolf is the
+// only member on one side of a game at least two others picked the other
+// way. Auto picks are left out, as autopick.Choose leaves them out, so a
+// member who missed the deadline is never a lone wolf for the scheduler's
+// choice. It returns nil when nobody has picked the game.
+func GameConsensus(game models.Game) *models.Consensus {
+	consensus := &models.Consensus{LoneWolves: []string{}}
+	var homeIDs, awayIDs []string
+	for _, pick := range game.Picks {
+		if pick.Auto {
+			continue
+		}
+		switch pick.ChosenSide {
+		case "home":
+			homeIDs = append(homeIDs, pick.MemberID)
+		case "away":
+			awayIDs = append(awayIDs, pick.MemberID)
+		}
+	}
+	consensus.HomePicks, consensus.AwayPicks = len(homeIDs), len(awayIDs)
+
+	total := consensus.HomePicks + consensus.AwayPicks
+	if total == 0 {
+		return nil
+	}
+	consensus.HomePct = float64(consensus.HomePicks) / float64(total)
+	consensus.AwayPct = float64(consensus.AwayPicks) / float64(total)
+
+	switch {
+	case consensus.HomePicks > consensus.AwayPicks:
+		consensus.Side = "home"
+	case consensus.AwayPicks > consensus.HomePicks:
+		consensus.Side = "away"
+	}
+	consensus.Unanimous = total > 1 && (consensus.HomePicks == 0 || consensus.AwayPicks == 0)
+
+	if len(homeIDs) == 1 && len(awayIDs) >= 2 {
+		consensus.LoneWolves = append(consensus.LoneWolves, homeIDs[0])
+	}
+	if len(awayIDs) == 1 && len(homeIDs) >= 2 {
+		consensus.LoneWolves = append(consensus.LoneWolves, awayIDs[0])
+	}
+	return consensus
+}
+
+// Summarize totals the week's consensus. Games must already carry their
+// Consensus; the consensus record grades the majority side of each graded
+// game, with pushes worth tiePoints.
+func Summarize(games []models.Game, tiePoints float64) models.WeekSummary {
+	summary := models.WeekSummary{Games: len(games)}
+	for _, game := range games {
+		summary.Picks += len(game.Picks)
+		consensus := game.Consensus
+		if consensus == nil {
+			continue
+		}
+		if consensus.Unanimous {
+			summary.UnanimousGames++
+		}
+		if consensus.Side == "" {
+			summary.SplitGames++
+		}
+
+		for _, pick := range game.Picks {
+			if consensus.Side != "" && pick.ChosenSide == consensus.Side {
+				addRecord(&summary.ConsensusRecord, pick.Status, tiePoints)
+				break
+			}
+		}
+		for _, memberID := range consensus.LoneWolves {
+			summary.LoneWolfPicks++
+			if _, status := pickFor(game, memberID); status == models.PickStatusCorrect {
+				summary.LoneWolfCorrect++
+			}
+		}
+	}
+	return summary
+}
@@ -0,0 +1,71 @@
+package stats
+
+import (
+	"reflect"
+	"testing"
+
+	"pickem/backend/internal/models"
+)
+
+func TestGameConsensus(t *testing.T) {
+	pick := func(memberID, side string, auto bool) models.GamePick {
+		return models.GamePick{MemberID: memberID, ChosenSide: side, Auto: auto}
+	}
+	tests := []struct {
+		name       string
+		picks      []models.GamePick
+		wantNil    bool
+		wantHome   int
+		wantAway   int
+		wantSide   string
+		wantWolves []string
+	}{
+		{
+			name:       "lone wolf",
+			picks:      []models.GamePick{pick("a", "home", false), pick("b", "home", false), pick("c", "away", false)},
+			wantHome:   2,
+			wantAway:   1,
+			wantSide:   "home",
+			wantWolves: []string{"c"},
+		},
+		{
+			name:       "auto pick is never a lone wolf",
+			picks:      []models.GamePick{pick("a", "home", false), pick("b", "home", false), pick("c", "away", true)},
+			wantHome:   2,
+			wantSide:   "home",
+			wantWolves: []string{},
+		},
+		{
+			name:       "auto picks do not make a majority",
+			picks:      []models.GamePick{pick("a", "home", false), pick("b", "away", true), pick("c", "away", true)},
+			wantHome:   1,
+			wantSide:   "home",
+			wantWolves: []string{},
+		},
+		{
+			name:    "only auto picks",
+			picks:   []models.GamePick{pick("a", "home", true)},
+			wantNil: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GameConsensus(models.Game{Picks: tt.picks})
+			if tt.wantNil {
+				if got != nil {
+					t.Fatalf("GameConsensus() = %+v, want nil", got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatal("GameConsensus() = nil")
+			}
+			if got.HomePicks != tt.wantHome || got.AwayPicks != tt.wantAway || got.Side != tt.wantSide {
+				t.Errorf("home %d away %d side %q, want %d %d %q", got.HomePicks, got.AwayPicks, got.Side, tt.wantHome, tt.wantAway, tt.wantSide)
+			}
+			if !reflect.DeepEqual(got.LoneWolves, tt.wantWolves) {
+				t.Errorf("lone wolves = %v, want %v", got.LoneWolves, tt.wantWolves)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package stats
+
+import (
+	"sort"
+
+	"pickem/backend/internal/models"
+	"pickem/backend/internal/standings"
+)
+
+// Hall of fame record categories.
+const (
+	RecordMostTitles    = "most-titles"
+	RecordMostWeeksWon  = "most-weeks-won"
+	RecordMostCorrect   = "most-correct"
+	RecordBestWeek      = "best-week"
+	RecordLongestStreak = "longest-streak"
+)
+
+// MemberInfo names a family member.
+type MemberInfo struct {
+	ID   string
+	Name string
+}
+
+// HallOfFame totals every member's career across the seasons, which must be
+// oldest first: streaks carry over from one season into the next.
+func HallOfFame(members []MemberInfo, seasons []Season) models.HallOfFame {
+	result := models.HallOfFame{
+		Seasons: len(seasons),
+		Members: make([]models.HallOfFameEntry, 0, len(members)),
+		Records: []models.HallOfFameRecord{},
+	}
+
+	for _, member := range members {
+		entry := models.HallOfFameEntry{
+			MemberID:     member.ID,
+			Name:         member.Name,
+			TitleSeasons: []string{},
+		}
+		for _, season := range seasons {
+			if season.TitleWinner == member.ID {
+				entry.Titles++
+				entry.TitleSeasons = append(entry.TitleSeasons, season.Label)
+			}
+
+			played := false
+			for _, week := range season.Weeks {
+				if season.WeekWinners[week.Number] == member.ID {
+					entry.WeeksWon++
+				}
+
+				var record models.RecordSummary
+				graded := false
+				for _, game := range week.Games {
+					side, status := pickFor(game, member.ID)
+					if side == "" {
+						continue
+					}
+					played = true
+					trackStreak(&entry.Streaks, status)
+					if addRecord(&record, status, season.TiePoints) {
+						graded = true
+					}
+				}
+				if !graded {
+					continue
+				}
+				mergeRecord(&entry.Record, record)
+				if entry.BestWeek == nil || record.Points > entry.BestWeek.Record.Points {
+					entry.BestWeek = &models.HallOfFameWeek{
+						SeasonID:    season.ID,
+						SeasonLabel: season.Label,
+						WeekNumber:  week.Number,
+						Record:      record,
+					}
+				}
+			}
+			if played {
+				entry.Seasons++
+			}
+		}
+		entry.WinPct = standings.WinPct(entry.Record)
+		result.Members = append(result.Members, entry)
+	}
+
+	sort.SliceStable(result.Members, func(i, j int) bool {
+		a, b := result.Members[i], result.Members[j]
+		switch {
+		case a.Titles != b.Titles:
+			return a.Titles > b.Titles
+		case a.WeeksWon != b.WeeksWon:
+			return a.WeeksWon > b.WeeksWon
+		case a.Record.Points != b.Record.Points:
+			return a.Record.Points > b.Record.Points
+		}
+		return a.Name < b.Name
+	})
+
+	categories := []struct {
+		name  string
+		value func(models.HallOfFameEntry) float64
+	}{
+		{RecordMostTitles, func(e models.HallOfFameEntry) float64 { return float64(e.Titles) }},
+		{RecordMostWeeksWon, func(e models.HallOfFameEntry) float64 { return float64(e.WeeksWon) }},
+		{RecordMostCorrect, func(e models.HallOfFameEntry) float64 { return float64(e.Record.Wins) }},
+		{RecordBestWeek, func(e models.HallOfFameEntry) float64 {
+			if e.BestWeek == nil {
+				return 0
+			}
+			return e.BestWeek.Record.Points
+		}},
+		{RecordLongestStreak, func(e models.HallOfFameEntry) float64 { return float64(e.Streaks.LongestCorrect) }},
+	}
+	for _, category := range categories {
+		if record, ok := recordFor(category.name, result.Members, category.value); ok {
+			result.Records = append(result.Records, record)
+		}
+	}
+
+	return result
+}
+
+// recordFor finds everyone sharing the best value in a category. Categories
+// nobody has scored in yet are left out.
+func recordFor(category string, entries []models.HallOfFameEntry, value func(models.HallOfFameEntry) float64) (models.HallOfFameRecord, bool) {
+	record := models.HallOfFameRecord{Category: category, Holders: []models.RecordHolder{}}
+	for _, entry := range entries {
+		v := value(entry)
+		if v <= 0 || v < record.Value {
+			continue
+		}
+		if v > record.Value {
+			record.Value = v
+			record.Holders = record.Holders[:0]
+		}
+		holder := models.RecordHolder{MemberID: entry.MemberID, Name: entry.Name}
+		if category == RecordBestWeek {
+			holder.SeasonID = entry.BestWeek.SeasonID
+			holder.SeasonLabel = entry.BestWeek.SeasonLabel
+			holder.WeekNumber = entry.BestWeek.WeekNumber
+		}
+		record.Holders = append(record.Holders, holder)
+	}
+	return record, len(record.Holders) > 0
+}
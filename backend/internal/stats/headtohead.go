@@ -0,0 +1,118 @@
+package stats
+
+import "pickem/backend/internal/models"
+
+// Season is one season's games along with what the head-to-head and
+// all-time views need to score them.
+type Season struct {
+	ID        string
+	Label     string
+	TiePoints float64
+	Weeks     []WeekGames
+	// WeekWinners maps week numbers to the declared winner's member ID.
+	WeekWinners map[int]string
+	// TitleWinner is the member declared season champion, if any.
+	TitleWinner string
+}
+
+// HeadToHead compares two members over the given seasons. A member finishes
+// a week ahead on points; when the points are level the declared week winner
+// takes it if it was one of the two. Disagreements are the games the two
+// picked on opposite sides, and the member who had it right wins them.
+func HeadToHead(memberID, opponentID string, seasons []Season) models.HeadToHead {
+	result := models.HeadToHead{
+		Member:        models.HeadToHeadSide{MemberID: memberID},
+		Opponent:      models.HeadToHeadSide{MemberID: opponentID},
+		Weeks:         []models.HeadToHeadWeek{},
+		Disagreements: []models.HeadToHeadGame{},
+	}
+
+	for _, season := range seasons {
+		for _, week := range season.Weeks {
+			var memberWeek, opponentWeek models.RecordSummary
+			graded := false
+			for _, game := range week.Games {
+				memberSide, memberStatus := pickFor(game, memberID)
+				opponentSide, opponentStatus := pickFor(game, opponentID)
+				if addRecord(&memberWeek, memberStatus, season.TiePoints) {
+					graded = true
+				}
+				if addRecord(&opponentWeek, opponentStatus, season.TiePoints) {
+					graded = true
+				}
+
+				if memberSide == "" || opponentSide == "" || memberSide == opponentSide {
+					continue
+				}
+				disagreement := models.HeadToHeadGame{
+					SeasonID:     season.ID,
+					WeekNumber:   week.Number,
+					GameKey:      game.GameKey,
+					HomeTeam:     game.HomeTeam.Code,
+					AwayTeam:     game.AwayTeam.Code,
+					MemberSide:   memberSide,
+					OpponentSide: opponentSide,
+				}
+				if memberStatus != models.PickStatusPending && memberStatus != models.PickStatusVoid {
+					disagreement.Winner = game.Winner
+				}
+				switch {
+				case memberStatus == models.PickStatusCorrect:
+					disagreement.WinnerID = memberID
+					result.Member.DisagreementsWon++
+				case opponentStatus == models.PickStatusCorrect:
+					disagreement.WinnerID = opponentID
+					result.Opponent.DisagreementsWon++
+				}
+				result.Disagreements = append(result.Disagreements, disagreement)
+			}
+
+			winner := season.WeekWinners[week.Number]
+			switch winner {
+			case memberID:
+				result.Member.WeeksWon++
+			case opponentID:
+				result.Opponent.WeeksWon++
+			}
+			if !graded && winner != memberID && winner != opponentID {
+				continue
+			}
+
+			mergeRecord(&result.Member.Record, memberWeek)
+			mergeRecord(&result.Opponent.Record, opponentWeek)
+
+			entry := models.HeadToHeadWeek{
+				SeasonID:       season.ID,
+				WeekNumber:     week.Number,
+				MemberPoints:   memberWeek.Points,
+				OpponentPoints: opponentWeek.Points,
+			}
+			switch {
+			case memberWeek.Points > opponentWeek.Points:
+				entry.LeaderID = memberID
+			case opponentWeek.Points > memberWeek.Points:
+				entry.LeaderID = opponentID
+			case winner == memberID || winner == opponentID:
+				entry.LeaderID = winner
+			}
+			switch entry.LeaderID {
+			case memberID:
+				result.Member.WeeksAhead++
+			case opponentID:
+				result.Opponent.WeeksAhead++
+			default:
+				result.WeeksLevel++
+			}
+			result.Weeks = append(result.Weeks, entry)
+		}
+	}
+
+	return result
+}
+
+func mergeRecord(total *models.RecordSummary, record models.RecordSummary) {
+	total.Wins += record.Wins
+	total.Losses += record.Losses
+	total.Ties += record.Ties
+	total.Points += record.Points
+}
@@ -0,0 +1,218 @@
+package stats
+
+import (
+	"sort"
+
+	"pickem/backend/internal/models"
+	"pickem/backend/internal/odds"
+	"pickem/backend/internal/standings"
+)
+
+// WeekGames is one week's games with every member's graded picks.
+type WeekGames struct {
+	ID     string
+	Number int
+	Games  []models.Game
+}
+
+// Member builds a member's season statistics from the weeks' games, in
+// kickoff order. tiePoints is what a push is worth under the season's tie
+// scoring; weeksWon marks the weeks the member was declared the winner.
+// Contrarian covers the picks that went against the other members' majority;
+// auto picks take no part in it, on either side.
+func Member(memberID string, weeks []WeekGames, weeksWon map[int]bool, tiePoints float64) models.MemberStats {
+	result := models.MemberStats{
+		MemberID: memberID,
+		Teams:    []models.TeamStats{},
+	}
+
+	teams := map[string]*models.TeamStats{}
+	team := func(code string) *models.TeamStats {
+		entry, ok := teams[code]
+		if !ok {
+			entry = &models.TeamStats{Team: code}
+			teams[code] = entry
+		}
+		return entry
+	}
+
+	weekly := []models.WeeklyRecord{}
+	for _, week := range weeks {
+		var record models.RecordSummary
+		graded := false
+		for _, game := range week.Games {
+			side, status := pickFor(game, memberID)
+			if side == "" {
+				continue
+			}
+
+			backed, opposed := game.HomeTeam.Code, game.AwayTeam.Code
+			if side == "away" {
+				backed, opposed = opposed, backed
+			}
+
+			add(&result.Overall, status, tiePoints)
+			if side == "home" {
+				add(&result.Home, status, tiePoints)
+			} else {
+				add(&result.Away, status, tiePoints)
+			}
+			switch favorite := odds.Favorite(game); {
+			case favorite == side:
+				add(&result.Favorite, status, tiePoints)
+			case favorite != "":
+				add(&result.Underdog, status, tiePoints)
+			}
+			if backed != "" {
+				add(&team(backed).Backed, status, tiePoints)
+				add(&team(backed).Games, status, tiePoints)
+			}
+			if opposed != "" {
+				add(&team(opposed).Against, status, tiePoints)
+				add(&team(opposed).Games, status, tiePoints)
+			}
+
+			if consensus := consensusSide(game, memberID); consensus != "" && !autoPicked(game, memberID) {
+				result.Consensus.Games++
+				if consensus == side {
+					result.Consensus.Agreed++
+				} else {
+					add(&result.Contrarian, status, tiePoints)
+				}
+			}
+
+			trackStreak(&result.Streaks, status)
+
+			if addRecord(&record, status, tiePoints) {
+				graded = true
+			}
+		}
+		if graded {
+			weekly = append(weekly, models.WeeklyRecord{
+				WeekNumber: week.Number,
+				Record:     record,
+				WonWeek:    weeksWon[week.Number],
+			})
+		}
+	}
+
+	for _, stat := range []*models.StatLine{&result.Overall, &result.Home, &result.Away, &result.Favorite, &result.Underdog, &result.Contrarian} {
+		stat.Accuracy = standings.WinPct(stat.Record)
+	}
+	for _, entry := range teams {
+		entry.Games.Accuracy = standings.WinPct(entry.Games.Record)
+		entry.Backed.Accuracy = standings.WinPct(entry.Backed.Record)
+		entry.Against.Accuracy = standings.WinPct(entry.Against.Record)
+		result.Teams = append(result.Teams, *entry)
+	}
+	sort.Slice(result.Teams, func(i, j int) bool {
+		if result.Teams[i].Games.Picks != result.Teams[j].Games.Picks {
+			return result.Teams[i].Games.Picks > result.Teams[j].Games.Picks
+		}
+		return result.Teams[i].Team < result.Teams[j].Team
+	})
+
+	if result.Consensus.Games > 0 {
+		result.Consensus.Rate = float64(result.Consensus.Agreed) / float64(result.Consensus.Games)
+	}
+
+	// Earlier weeks win ties for both best and worst.
+	for i := range weekly {
+		if result.BestWeek == nil || weekly[i].Record.Points > result.BestWeek.Record.Points {
+			result.BestWeek = &weekly[i]
+		}
+		if result.WorstWeek == nil || weekly[i].Record.Points < result.WorstWeek.Record.Points {
+			result.WorstWeek = &weekly[i]
+		}
+	}
+
+	return result
+}
+
+// trackStreak extends the running streak with a pick. Current is positive
+// for a run of correct picks and negative for a run of misses; a push ends
+// either, while pending and void picks leave it alone.
+func trackStreak(streaks *models.StreakStats, status string) {
+	switch status {
+	case models.PickStatusCorrect:
+		if streaks.Current < 0 {
+			streaks.Current = 0
+		}
+		streaks.Current++
+		streaks.LongestCorrect = max(streaks.LongestCorrect, streaks.Current)
+	case models.PickStatusIncorrect:
+		if streaks.Current > 0 {
+			streaks.Current = 0
+		}
+		streaks.Current--
+		streaks.LongestIncorrect = max(streaks.LongestIncorrect, -streaks.Current)
+	case models.PickStatusPush:
+		streaks.Current = 0
+	}
+}
+
+func pickFor(game models.Game, memberID string) (string, string) {
+	for _, pick := range game.Picks {
+		if pick.MemberID == memberID {
+			return pick.ChosenSide, pick.Status
+		}
+	}
+	return "", ""
+}
+
+// autoPicked reports whether the scheduler made memberID's pick.
+func autoPicked(game models.Game, memberID string) bool {
+	for _, pick := range game.Picks {
+		if pick.MemberID == memberID {
+			return pick.Auto
+		}
+	}
+	return false
+}
+
+// consensusSide is the side most of the other members took themselves, or
+// "" when they split evenly or nobody else picked the game.
+func consensusSide(game models.Game, memberID string) string {
+	home, away := 0, 0
+	for _, pick := range game.Picks {
+		if pick.MemberID == memberID || pick.Auto {
+			continue
+		}
+		switch pick.ChosenSide {
+		case "home":
+			home++
+		case "away":
+			away++
+		}
+	}
+	switch {
+	case home > away:
+		return "home"
+	case away > home:
+		return "away"
+	}
+	return ""
+}
+
+// add counts a pick on the stat line and, once graded, in its record.
+func add(stat *models.StatLine, status string, tiePoints float64) {
+	stat.Picks++
+	addRecord(&stat.Record, status, tiePoints)
+}
+
+// addRecord grades a pick into the record, reporting whether it counted.
+func addRecord(record *models.RecordSummary, status string, tiePoints float64) bool {
+	switch status {
+	case models.PickStatusCorrect:
+		record.Wins++
+		record.Points++
+	case models.PickStatusIncorrect:
+		record.Losses++
+	case models.PickStatusPush:
+		record.Ties++
+		record.Points += tiePoints
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,540 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"pickem/backend/internal/archive"
+	"pickem/backend/internal/models"
+	"pickem/backend/internal/nfl"
+)
+
+// ExportArchive copies the whole pool, or just one season when seasonID is
+// set, into a portable archive. Every member is included either way so the
+// archive restores into an empty database.
+func (s *Store) ExportArchive(ctx context.Context, seasonID string) (*archive.Archive, error) {
+	out := &archive.Archive{
+		Version:    archive.Version,
+		ExportedAt: time.Now().UTC(),
+		Members:    []archive.Member{},
+		Seasons:    []archive.Season{},
+	}
+
+	rows, err := s.pool.Query(ctx, `
+		select id, name, is_commissioner
+		from family_members
+		order by name asc
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("store: archive members: %w", err)
+	}
+	for rows.Next() {
+		var member archive.Member
+		if err := rows.Scan(&member.ID, &member.Name, &member.IsCommissioner); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("store: archive scan member: %w", err)
+		}
+		out.Members = append(out.Members, member)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("store: archive members: %w", err)
+	}
+
+	seasons, err := s.ListSeasons(ctx)
+	if err != nil {
+		return nil, err
+	}
+	found := false
+	for i := len(seasons) - 1; i >= 0; i-- {
+		season := seasons[i]
+		if seasonID != "" && season.ID != seasonID {
+			continue
+		}
+		found = true
+		archived, err := s.archiveSeason(ctx, season)
+		if err != nil {
+			return nil, err
+		}
+		out.Seasons = append(out.Seasons, *archived)
+	}
+	if seasonID != "" && !found {
+		return nil, ErrSeasonNotFound
+	}
+
+	return out, nil
+}
+
+func (s *Store) archiveSeason(ctx context.Context, season models.Season) (*archive.Season, error) {
+	out := &archive.Season{
+		ID:        season.ID,
+		Label:     season.Label,
+		Year:      season.Year,
+		SeasonKey: season.SportsDataSeasonKey,
+		Weeks:     []archive.Week{},
+	}
+
+	settings, err := s.getSeasonSettings(ctx, season.ID)
+	if err != nil {
+		return nil, err
+	}
+	out.Settings = &archive.Settings{
+		CurrentWeek:   settings.CurrentWeek,
+		TieScoring:    settings.TieScoring,
+		StandingsRule: settings.StandingsRule,
+		AutoPickRule:  settings.AutoPickRule,
+	}
+
+	weekIndex := map[string]int{}
+	rows, err := s.pool.Query(ctx, `
+		select id, number, label, starts_at, ends_at
+		from season_weeks
+		where season_id = $1
+		order by number asc
+	`, season.ID)
+	if err != nil {
+		return nil, fmt.Errorf("store: archive weeks: %w", err)
+	}
+	for rows.Next() {
+		week := archive.Week{Games: []archive.Game{}, TieBreakers: []archive.TieBreaker{}}
+		if err := rows.Scan(&week.ID, &week.Number, &week.Label, &week.StartsAt, &week.EndsAt); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("store: archive scan week: %w", err)
+		}
+		weekIndex[week.ID] = len(out.Weeks)
+		out.Weeks = append(out.Weeks, week)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("store: archive weeks: %w", err)
+	}
+
+	type gameRef struct{ week, game int }
+	gameIndex := map[string]gameRef{}
+	rows, err = s.pool.Query(ctx, `
+		select g.id, g.season_week_id, g.game_key, g.kickoff, g.status,
+			coalesce(g.channel, ''), coalesce(g.location, ''),
+			g.home_team, g.away_team, g.home_score, g.away_score,
+			coalesce(g.winner, ''), g.result_source, coalesce(g.result_reason, ''),
+			mw.number, g.point_spread, g.over_under, g.home_moneyline, g.away_moneyline
+		from games g
+			join season_weeks w on w.id = g.season_week_id
+			left join season_weeks mw on mw.id = g.moved_from_week_id
+		where w.season_id = $1
+		order by g.kickoff asc nulls last, g.game_key asc
+	`, season.ID)
+	if err != nil {
+		return nil, fmt.Errorf("store: archive games: %w", err)
+	}
+	for rows.Next() {
+		var (
+			game         archive.Game
+			seasonWeekID string
+			homeTeamB    []byte
+			awayTeamB    []byte
+		)
+		if err := rows.Scan(
+			&game.ID, &seasonWeekID, &game.GameKey, &game.Kickoff, &game.Status,
+			&game.Channel, &game.Location, &homeTeamB, &awayTeamB,
+			&game.HomeScore, &game.AwayScore, &game.Winner, &game.ResultSource, &game.ResultReason,
+			&game.MovedFromWeek, &game.PointSpread, &game.OverUnder, &game.HomeMoneyline, &game.AwayMoneyline,
+		); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("store: archive scan game: %w", err)
+		}
+		var home, away models.TeamInfo
+		if err := json.Unmarshal(homeTeamB, &home); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("store: archive decode home team: %w", err)
+		}
+		if err := json.Unmarshal(awayTeamB, &away); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("store: archive decode away team: %w", err)
+		}
+		game.HomeTeam, game.AwayTeam = home.Code, away.Code
+		game.Picks = []archive.Pick{}
+
+		idx := weekIndex[seasonWeekID]
+		gameIndex[game.ID] = gameRef{week: idx, game: len(out.Weeks[idx].Games)}
+		out.Weeks[idx].Games = append(out.Weeks[idx].Games, game)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("store: archive games: %w", err)
+	}
+
+	rows, err = s.pool.Query(ctx, `
+		select p.game_id, p.member_id, p.chosen_side, p.is_auto, coalesce(p.entered_by::text, '')
+		from picks p
+			join games g on g.id = p.game_id
+			join season_weeks w on w.id = g.season_week_id
+		where w.season_id = $1
+		order by p.created_at asc
+	`, season.ID)
+	if err != nil {
+		return nil, fmt.Errorf("store: archive picks: %w", err)
+	}
+	for rows.Next() {
+		var (
+			gameID string
+			pick   archive.Pick
+		)
+		if err := rows.Scan(&gameID, &pick.MemberID, &pick.Side, &pick.Auto, &pick.EnteredBy); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("store: archive scan pick: %w", err)
+		}
+		ref := gameIndex[gameID]
+		game := &out.Weeks[ref.week].Games[ref.game]
+		game.Picks = append(game.Picks, pick)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("store: archive picks: %w", err)
+	}
+
+	rows, err = s.pool.Query(ctx, `
+		select t.season_week_id, t.member_id, t.points
+		from tie_breakers t
+			join season_weeks w on w.id = t.season_week_id
+		where w.season_id = $1
+	`, season.ID)
+	if err != nil {
+		return nil, fmt.Errorf("store: archive tie-breakers: %w", err)
+	}
+	for rows.Next() {
+		var (
+			seasonWeekID string
+			tb           archive.TieBreaker
+		)
+		if err := rows.Scan(&seasonWeekID, &tb.MemberID, &tb.Points); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("store: archive scan tie-breaker: %w", err)
+		}
+		week := &out.Weeks[weekIndex[seasonWeekID]]
+		week.TieBreakers = append(week.TieBreakers, tb)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("store: archive tie-breakers: %w", err)
+	}
+
+	rows, err = s.pool.Query(ctx, `
+		select wr.season_week_id, coalesce(wr.winner_member_id::text, ''), coalesce(wr.notes, ''),
+			coalesce(wr.declared_by_member_id::text, ''), wr.declared_at
+		from week_results wr
+			join season_weeks w on w.id = wr.season_week_id
+		where w.season_id = $1
+	`, season.ID)
+	if err != nil {
+		return nil, fmt.Errorf("store: archive week results: %w", err)
+	}
+	for rows.Next() {
+		var (
+			seasonWeekID string
+			result       archive.Result
+		)
+		if err := rows.Scan(&seasonWeekID, &result.WinnerID, &result.Notes, &result.DeclaredByID, &result.DeclaredAt); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("store: archive scan week result: %w", err)
+		}
+		out.Weeks[weekIndex[seasonWeekID]].Result = &result
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("store: archive week results: %w", err)
+	}
+
+	var title archive.Result
+	err = s.pool.QueryRow(ctx, `
+		select coalesce(winner_member_id::text, ''), coalesce(notes, ''),
+			coalesce(declared_by_member_id::text, ''), declared_at
+		from season_titles
+		where season_id = $1
+	`, season.ID).Scan(&title.WinnerID, &title.Notes, &title.DeclaredByID, &title.DeclaredAt)
+	switch {
+	case err == nil:
+		out.Title = &title
+	case !errors.Is(err, pgx.ErrNoRows):
+		return nil, fmt.Errorf("store: archive season title: %w", err)
+	}
+
+	return out, nil
+}
+
+// RestoreArchive loads an archive into the database in one transaction.
+// Records are matched on their natural keys (member name, season key, week
+// number, game key) rather than their archived IDs, so the archive can be
+// restored into an empty database, back into the one it came from, or
+// twice in a row with the same result. Existing members keep their
+// commissioner flag. Game tags and ratings for the restored seasons are
+// rebuilt afterwards.
+func (s *Store) RestoreArchive(ctx context.Context, a *archive.Archive) (*models.ArchiveRestore, error) {
+	if err := a.Validate(); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("store: restore begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	report := &models.ArchiveRestore{Members: len(a.Members), Seasons: len(a.Seasons)}
+
+	// IDs in the archive map onto IDs in this database.
+	memberIDs := map[string]string{}
+	for _, member := range a.Members {
+		id, created, err := importMember(ctx, tx, member.Name)
+		if err != nil {
+			return nil, err
+		}
+		if created {
+			report.MembersCreated++
+			if member.IsCommissioner {
+				if _, err := tx.Exec(ctx, `
+					update family_members set is_commissioner = true where id = $1
+				`, id); err != nil {
+					return nil, fmt.Errorf("store: restore commissioner %q: %w", member.Name, err)
+				}
+			}
+		}
+		memberIDs[member.ID] = id
+	}
+	member := func(archived string) any {
+		if archived == "" {
+			return nil
+		}
+		return memberIDs[archived]
+	}
+
+	var restoredWeeks []string
+	for _, season := range a.Seasons {
+		var seasonID string
+		err := tx.QueryRow(ctx, `
+			select id from seasons where sportsdata_season_key = $1
+		`, season.SeasonKey).Scan(&seasonID)
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			err = tx.QueryRow(ctx, `
+				insert into seasons (label, season_year, sportsdata_season_key)
+				values ($1, $2, $3)
+				returning id
+			`, season.Label, season.Year, season.SeasonKey).Scan(&seasonID)
+			if err != nil {
+				return nil, fmt.Errorf("store: restore season %s: %w", season.SeasonKey, err)
+			}
+			report.SeasonsCreated++
+		case err != nil:
+			return nil, fmt.Errorf("store: restore lookup season %s: %w", season.SeasonKey, err)
+		default:
+			if _, err := tx.Exec(ctx, `
+				update seasons set label = $2, season_year = $3 where id = $1
+			`, seasonID, season.Label, season.Year); err != nil {
+				return nil, fmt.Errorf("store: restore season %s: %w", season.SeasonKey, err)
+			}
+		}
+
+		if settings := season.Settings; settings != nil {
+			if _, err := tx.Exec(ctx, `
+				insert into season_settings (season_id, current_week, tie_scoring, standings_rule, auto_pick_rule)
+				values ($1, greatest($2, 1), coalesce(nullif($3, ''), 'zero'), coalesce(nullif($4, ''), 'weeks-won'), coalesce(nullif($5, ''), 'none'))
+				on conflict (season_id)
+				do update set
+					current_week = excluded.current_week,
+					tie_scoring = excluded.tie_scoring,
+					standings_rule = excluded.standings_rule,
+					auto_pick_rule = excluded.auto_pick_rule
+			`, seasonID, settings.CurrentWeek, settings.TieScoring, settings.StandingsRule, settings.AutoPickRule); err != nil {
+				return nil, fmt.Errorf("store: restore %s settings: %w", season.SeasonKey, err)
+			}
+		} else if _, err := tx.Exec(ctx, `
+			insert into season_settings (season_id, current_week)
+			values ($1, 1)
+			on conflict (season_id) do nothing
+		`, seasonID); err != nil {
+			return nil, fmt.Errorf("store: restore %s settings: %w", season.SeasonKey, err)
+		}
+
+		// Weeks first, so games moved between weeks can point at either.
+		weekIDs := map[int]string{}
+		for _, week := range season.Weeks {
+			var weekID string
+			if err := tx.QueryRow(ctx, `
+				insert into season_weeks (season_id, number, label, starts_at, ends_at)
+				values ($1, $2, $3, $4, $5)
+				on conflict (season_id, number)
+				do update set label = excluded.label, starts_at = excluded.starts_at, ends_at = excluded.ends_at
+				returning id
+			`, seasonID, week.Number, week.Label, week.StartsAt, week.EndsAt).Scan(&weekID); err != nil {
+				return nil, fmt.Errorf("store: restore %s week %d: %w", season.SeasonKey, week.Number, err)
+			}
+			weekIDs[week.Number] = weekID
+			restoredWeeks = append(restoredWeeks, weekID)
+			report.Weeks++
+		}
+
+		for _, week := range season.Weeks {
+			weekID := weekIDs[week.Number]
+			for _, game := range week.Games {
+				if err := restoreGame(ctx, tx, seasonID, weekID, weekIDs, game, memberIDs, report); err != nil {
+					return nil, err
+				}
+			}
+
+			for _, tb := range week.TieBreakers {
+				if _, err := tx.Exec(ctx, `
+					insert into tie_breakers (member_id, season_week_id, points)
+					values ($1, $2, $3)
+					on conflict (member_id, season_week_id)
+					do update set points = excluded.points
+				`, memberIDs[tb.MemberID], weekID, tb.Points); err != nil {
+					return nil, fmt.Errorf("store: restore tie-breaker: %w", err)
+				}
+				report.TieBreakers++
+			}
+
+			if result := week.Result; result != nil {
+				if _, err := tx.Exec(ctx, `
+					insert into week_results (season_week_id, winner_member_id, notes, declared_by_member_id, declared_at)
+					values ($1, $2, nullif($3, ''), $4, $5)
+					on conflict (season_week_id)
+					do update set
+						winner_member_id = excluded.winner_member_id,
+						notes = excluded.notes,
+						declared_by_member_id = excluded.declared_by_member_id,
+						declared_at = excluded.declared_at
+				`, weekID, member(result.WinnerID), result.Notes, member(result.DeclaredByID), result.DeclaredAt); err != nil {
+					return nil, fmt.Errorf("store: restore %s week %d result: %w", season.SeasonKey, week.Number, err)
+				}
+				report.WeekResults++
+			}
+		}
+
+		if title := season.Title; title != nil {
+			if _, err := tx.Exec(ctx, `
+				insert into season_titles (season_id, winner_member_id, notes, declared_by_member_id, declared_at)
+				values ($1, $2, nullif($3, ''), $4, $5)
+				on conflict (season_id)
+				do update set
+					winner_member_id = excluded.winner_member_id,
+					notes = excluded.notes,
+					declared_by_member_id = excluded.declared_by_member_id,
+					declared_at = excluded.declared_at
+			`, seasonID, member(title.WinnerID), title.Notes, member(title.DeclaredByID), title.DeclaredAt); err != nil {
+				return nil, fmt.Errorf("store: restore %s title: %w", season.SeasonKey, err)
+			}
+			report.Titles++
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("store: restore commit: %w", err)
+	}
+
+	for _, weekID := range restoredWeeks {
+		if err := s.RefreshGameTags(ctx, weekID); err != nil {
+			return report, err
+		}
+	}
+	if err := s.RecomputeRatings(ctx); err != nil {
+		return report, err
+	}
+	return report, nil
+}
+
+func restoreGame(ctx context.Context, tx pgx.Tx, seasonID, weekID string, weekIDs map[int]string, game archive.Game, memberIDs map[string]string, report *models.ArchiveRestore) error {
+	var owner string
+	err := tx.QueryRow(ctx, `
+		select w.season_id
+		from games g
+			join season_weeks w on w.id = g.season_week_id
+		where g.game_key = $1
+	`, game.GameKey).Scan(&owner)
+	if err == nil && owner != seasonID {
+		return fmt.Errorf("%w: game %s belongs to another season", ErrImportConflict, game.GameKey)
+	}
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("store: restore lookup game %s: %w", game.GameKey, err)
+	}
+
+	homeTeam, err := json.Marshal(nfl.Lookup(game.HomeTeam))
+	if err != nil {
+		return fmt.Errorf("store: restore marshal home team: %w", err)
+	}
+	awayTeam, err := json.Marshal(nfl.Lookup(game.AwayTeam))
+	if err != nil {
+		return fmt.Errorf("store: restore marshal away team: %w", err)
+	}
+	var movedFrom any
+	if game.MovedFromWeek != nil {
+		if id, ok := weekIDs[*game.MovedFromWeek]; ok {
+			movedFrom = id
+		}
+	}
+	source := strings.TrimSpace(game.ResultSource)
+	if source == "" {
+		source = models.ResultSourceProvider
+	}
+
+	var gameID string
+	if err := tx.QueryRow(ctx, `
+		insert into games (
+			season_week_id, game_key, kickoff, status, channel, location, home_team, away_team,
+			home_score, away_score, winner, result_source, result_reason, moved_from_week_id,
+			point_spread, over_under, home_moneyline, away_moneyline
+		)
+		values ($1, $2, $3, $4, nullif($5, ''), nullif($6, ''), $7, $8, $9, $10, nullif($11, ''),
+			$12, nullif($13, ''), $14, $15, $16, $17, $18)
+		on conflict (game_key)
+		do update set
+			season_week_id = excluded.season_week_id,
+			kickoff = excluded.kickoff,
+			status = excluded.status,
+			channel = excluded.channel,
+			location = excluded.location,
+			home_team = excluded.home_team,
+			away_team = excluded.away_team,
+			home_score = excluded.home_score,
+			away_score = excluded.away_score,
+			winner = excluded.winner,
+			result_source = excluded.result_source,
+			result_reason = excluded.result_reason,
+			moved_from_week_id = excluded.moved_from_week_id,
+			point_spread = excluded.point_spread,
+			over_under = excluded.over_under,
+			home_moneyline = excluded.home_moneyline,
+			away_moneyline = excluded.away_moneyline
+		returning id
+	`, weekID, game.GameKey, game.Kickoff, game.Status, game.Channel, game.Location, homeTeam, awayTeam,
+		game.HomeScore, game.AwayScore, game.Winner, source, game.ResultReason, movedFrom,
+		game.PointSpread, game.OverUnder, game.HomeMoneyline, game.AwayMoneyline).Scan(&gameID); err != nil {
+		return fmt.Errorf("store: restore game %s: %w", game.GameKey, err)
+	}
+	report.Games++
+
+	for _, pick := range game.Picks {
+		var enteredBy any
+		if pick.EnteredBy != "" {
+			enteredBy = memberIDs[pick.EnteredBy]
+		}
+		if _, err := tx.Exec(ctx, `
+			insert into picks (member_id, game_id, chosen_side, is_auto, entered_by)
+			values ($1, $2, $3, $4, $5)
+			on conflict (member_id, game_id)
+			do update set
+				chosen_side = excluded.chosen_side,
+				is_auto = excluded.is_auto,
+				entered_by = excluded.entered_by
+		`, memberIDs[pick.MemberID], gameID, pick.Side, pick.Auto, enteredBy); err != nil {
+			return fmt.Errorf("store: restore pick on %s: %w", game.GameKey, err)
+		}
+		report.Picks++
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"pickem/backend/internal/autopick"
+	"pickem/backend/internal/models"
+)
+
+// AutoPick is a pick ApplyAutoPicks made on a member's behalf.
+type AutoPick struct {
+	GameKey string
+	Pick    models.GamePick
+}
+
+// ApplyAutoPicks fills in picks for members who missed a game that has
+// locked, using the season's auto-pick rule. Existing picks are never
+// replaced. It returns the picks inserted.
+func (s *Store) ApplyAutoPicks(ctx context.Context, seasonID string, weekNumber int, now time.Time) ([]AutoPick, error) {
+	settings, err := s.getSeasonSettings(ctx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+	if settings.AutoPickRule == autopick.RuleNone {
+		return nil, nil
+	}
+
+	week, err := s.getWeekByNumber(ctx, seasonID, weekNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	games, err := s.listGamesWithPicks(ctx, week.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The standings double as the member list; the leader is only faded
+	// when they hold first place alone.
+	table, err := s.GetStandings(ctx, seasonID, "", 0)
+	if err != nil {
+		return nil, err
+	}
+	leaderID := ""
+	if len(table.Entries) > 0 && !table.Entries[0].Tied {
+		leaderID = table.Entries[0].MemberID
+	}
+
+	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("store: auto picks begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var inserted []AutoPick
+	for _, game := range games {
+		if !gameLocked(game, now) {
+			continue
+		}
+
+		picked := map[string]bool{}
+		for _, pick := range game.Picks {
+			picked[pick.MemberID] = true
+		}
+
+		for _, entry := range table.Entries {
+			if picked[entry.MemberID] {
+				continue
+			}
+			side := autopick.Choose(settings.AutoPickRule, game, entry.MemberID, leaderID)
+			if side == "" {
+				continue
+			}
+			tag, err := tx.Exec(ctx, `
+				insert into picks (member_id, game_id, chosen_side, is_auto)
+				values ($1, $2, $3, true)
+				on conflict (member_id, game_id) do nothing
+			`, entry.MemberID, game.ID, side)
+			if err != nil {
+				return nil, fmt.Errorf("store: insert auto pick: %w", err)
+			}
+			if tag.RowsAffected() == 0 {
+				continue
+			}
+			if err := recordPickHistory(ctx, tx, entry.MemberID, game.ID, models.PickActionAuto, side, ""); err != nil {
+				return nil, err
+			}
+			inserted = append(inserted, AutoPick{
+				GameKey: game.GameKey,
+				Pick: models.GamePick{
+					MemberID:   entry.MemberID,
+					ChosenSide: side,
+					Status:     pickStatus(game, side),
+					Auto:       true,
+				},
+			})
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("store: auto picks commit: %w", err)
+	}
+	return inserted, nil
+}
+
+// gameLocked reports whether picks on the game have closed but the result
+// is not in yet. Graded games are never auto-picked: the pick would be made
+// knowing the outcome. Canceled and postponed games are left alone too;
+// there is nothing to pick yet.
+func gameLocked(game models.Game, now time.Time) bool {
+	switch game.Status {
+	case models.GameStatusScheduled:
+		return game.Kickoff != nil && !game.Kickoff.After(now)
+	case models.GameStatusInProgress, models.GameStatusSuspended:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+package store
+
+import (
+	"testing"
+	"time"
+
+	"pickem/backend/internal/models"
+)
+
+func TestGameLocked(t *testing.T) {
+	now := time.Date(2024, 9, 8, 18, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Minute)
+	future := now.Add(time.Minute)
+
+	tests := []struct {
+		name    string
+		status  string
+		kickoff *time.Time
+		want    bool
+	}{
+		{"scheduled before kickoff", models.GameStatusScheduled, &future, false},
+		{"scheduled at kickoff", models.GameStatusScheduled, &now, true},
+		{"scheduled after kickoff", models.GameStatusScheduled, &past, true},
+		{"scheduled without kickoff", models.GameStatusScheduled, nil, false},
+		{"in progress", models.GameStatusInProgress, &past, true},
+		{"suspended", models.GameStatusSuspended, &past, true},
+		{"final", models.GameStatusFinal, &past, false},
+		{"forfeit", models.GameStatusForfeit, &past, false},
+		{"canceled", models.GameStatusCanceled, &past, false},
+		{"postponed", models.GameStatusPostponed, &past, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := models.Game{Status: tt.status, Kickoff: tt.kickoff}
+			if got := gameLocked(game, now); got != tt.want {
+				t.Errorf("gameLocked(%s) = %v, want %v", tt.status, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPicksOpen(t *testing.T) {
+	now := time.Date(2024, 9, 8, 18, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Minute)
+	future := now.Add(time.Minute)
+
+	tests := []struct {
+		name    string
+		status  string
+		kickoff *time.Time
+		want    bool
+	}{
+		{"scheduled before kickoff", models.GameStatusScheduled, &future, true},
+		{"scheduled without kickoff", models.GameStatusScheduled, nil, true},
+		{"scheduled at kickoff", models.GameStatusScheduled, &now, false},
+		{"scheduled after kickoff", models.GameStatusScheduled, &past, false},
+		{"in progress before listed kickoff", models.GameStatusInProgress, &future, false},
+		{"final", models.GameStatusFinal, &future, false},
+		{"postponed", models.GameStatusPostponed, &future, false},
+		{"canceled", models.GameStatusCanceled, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := picksOpen(tt.status, tt.kickoff, now); got != tt.want {
+				t.Errorf("picksOpen(%s) = %v, want %v", tt.status, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package store
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"pickem/backend/internal/calendar"
+	"pickem/backend/internal/models"
+)
+
+// GetSeasonCalendar builds the season's game and pick-deadline feed. With
+// a memberID the feed carries that member's picks.
+func (s *Store) GetSeasonCalendar(ctx context.Context, seasonID, memberID string) (*calendar.Calendar, error) {
+	season, err := s.getSeason(ctx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	input := calendar.Input{
+		Season:   *season,
+		MemberID: strings.TrimSpace(memberID),
+		PicksOpen: func(game models.Game) bool {
+			return picksOpen(game.Status, game.Kickoff, now)
+		},
+	}
+	if input.MemberID != "" {
+		name, err := s.getMemberName(ctx, input.MemberID)
+		if err != nil {
+			return nil, err
+		}
+		input.MemberName = name
+	}
+
+	weeks, err := s.listSeasonWeeks(ctx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+	for _, week := range weeks {
+		games, err := s.listGamesWithPicks(ctx, week.ID)
+		if err != nil {
+			return nil, err
+		}
+		input.Weeks = append(input.Weeks, calendar.Week{Number: week.Number, Label: week.Label, Games: games})
+	}
+
+	cal := calendar.Build(input)
+	return &cal, nil
+}
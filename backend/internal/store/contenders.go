@@ -0,0 +1,149 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"pickem/backend/internal/contenders"
+	"pickem/backend/internal/models"
+)
+
+// defaultSlateSize stands in for weeks whose games have not been synced yet.
+const defaultSlateSize = 16
+
+// GetContenders works out who can still win the given week and the season
+// title.
+func (s *Store) GetContenders(ctx context.Context, seasonID string, weekNumber int, allowTies bool) (*models.ContenderReport, error) {
+	if _, err := s.getSeason(ctx, seasonID); err != nil {
+		return nil, err
+	}
+
+	week, err := s.getWeekByNumber(ctx, seasonID, weekNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := s.getSeasonSettings(ctx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := s.loadMemberWeeks(ctx, seasonID, settings.TieScoring)
+	if err != nil {
+		return nil, err
+	}
+
+	games, err := s.listGamesWithPicks(ctx, week.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	memberIDs := make([]string, 0, len(members))
+	current := map[string]float64{}
+	for _, member := range members {
+		memberIDs = append(memberIDs, member.MemberID)
+		current[member.MemberID] = member.Records[week.Number].Points
+	}
+
+	remaining := remainingGames(games)
+
+	remainingWeeks, remainingSeasonGames, err := s.seasonRemaining(ctx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	seasonMembers := make([]contenders.SeasonMember, 0, len(members))
+	for _, member := range members {
+		seasonMember := contenders.SeasonMember{MemberID: member.MemberID, WeeksWon: len(member.WeeksWon)}
+		for _, record := range member.Records {
+			seasonMember.Record.Wins += record.Wins
+			seasonMember.Record.Losses += record.Losses
+			seasonMember.Record.Ties += record.Ties
+			seasonMember.Record.Points += record.Points
+		}
+		seasonMembers = append(seasonMembers, seasonMember)
+	}
+
+	return &models.ContenderReport{
+		SeasonID:       seasonID,
+		WeekNumber:     week.Number,
+		AllowTies:      allowTies,
+		RemainingGames: len(remaining),
+		Week:           contenders.Week(memberIDs, current, remaining, allowTies, tiePointValue(settings.TieScoring)),
+		SeasonRule:     settings.StandingsRule,
+		RemainingWeeks: remainingWeeks,
+		Season:         contenders.Season(settings.StandingsRule, seasonMembers, remainingWeeks, remainingSeasonGames, allowTies),
+	}, nil
+}
+
+// remainingGames lists the games whose picks can still change hands:
+// anything not graded or canceled.
+func remainingGames(games []models.Game) []contenders.RemainingGame {
+	remaining := []contenders.RemainingGame{}
+	for _, game := range games {
+		if isGradedStatus(game.Status) || game.Status == models.GameStatusCanceled {
+			continue
+		}
+		entry := contenders.RemainingGame{GameKey: game.GameKey}
+		for _, pick := range game.Picks {
+			entry.Picks = append(entry.Picks, contenders.Pick{MemberID: pick.MemberID, Side: pick.ChosenSide})
+		}
+		remaining = append(remaining, entry)
+	}
+	return remaining
+}
+
+// seasonRemaining counts the weeks without a declared result and the games
+// still to be graded. Weeks that have not been synced count as an average
+// slate.
+func (s *Store) seasonRemaining(ctx context.Context, seasonID string) (int, int, error) {
+	rows, err := s.pool.Query(ctx, `
+		select
+			w.number,
+			exists (select 1 from week_results wr where wr.season_week_id = w.id) as declared,
+			count(g.id) as games,
+			count(g.id) filter (where g.status not in ('final', 'forfeit', 'canceled')) as open_games
+		from season_weeks w
+			left join games g on g.season_week_id = w.id
+		where w.season_id = $1
+		group by w.id, w.number
+	`, seasonID)
+	if err != nil {
+		return 0, 0, fmt.Errorf("store: season remaining: %w", err)
+	}
+	defer rows.Close()
+
+	var (
+		remainingWeeks int
+		openGames      int
+		syncedGames    int
+		syncedWeeks    int
+		unsyncedWeeks  int
+	)
+	for rows.Next() {
+		var number, games, open int
+		var declared bool
+		if err := rows.Scan(&number, &declared, &games, &open); err != nil {
+			return 0, 0, fmt.Errorf("store: season remaining scan: %w", err)
+		}
+		if !declared {
+			remainingWeeks++
+		}
+		if games == 0 {
+			unsyncedWeeks++
+			continue
+		}
+		syncedWeeks++
+		syncedGames += games
+		openGames += open
+	}
+	if err := rows.Err(); err != nil {
+		return 0, 0, err
+	}
+
+	slate := defaultSlateSize
+	if syncedWeeks > 0 {
+		slate = (syncedGames + syncedWeeks - 1) / syncedWeeks
+	}
+	return remainingWeeks, openGames + unsyncedWeeks*slate, nil
+}
@@ -0,0 +1,73 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"pickem/backend/internal/feed"
+)
+
+// GetSeasonFeed builds the season's Atom feed of declared week winners and
+// the season title. selfURL is where the feed is served from.
+func (s *Store) GetSeasonFeed(ctx context.Context, seasonID, selfURL string) (*feed.Feed, error) {
+	season, err := s.getSeason(ctx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := s.getSeasonSettings(ctx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := s.loadMemberWeeks(ctx, seasonID, settings.TieScoring)
+	if err != nil {
+		return nil, err
+	}
+
+	input := feed.Input{Season: *season, Members: members, SelfURL: selfURL}
+
+	rows, err := s.pool.Query(ctx, `
+		select w.number, w.label, wr.id, coalesce(wr.winner_member_id::text, ''), coalesce(wr.notes, ''), wr.declared_at
+		from week_results wr
+			join season_weeks w on w.id = wr.season_week_id
+		where w.season_id = $1
+		order by w.number asc
+	`, seasonID)
+	if err != nil {
+		return nil, fmt.Errorf("store: feed week results: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var week feed.Week
+		var result feed.Result
+		if err := rows.Scan(&week.Number, &week.Label, &result.ID, &result.WinnerID, &result.Notes, &result.DeclaredAt); err != nil {
+			return nil, fmt.Errorf("store: feed scan week result: %w", err)
+		}
+		week.Result = &result
+		input.Weeks = append(input.Weeks, week)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("store: feed week results: %w", err)
+	}
+
+	var title feed.Result
+	err = s.pool.QueryRow(ctx, `
+		select id, coalesce(winner_member_id::text, ''), coalesce(notes, ''), declared_at
+		from season_titles
+		where season_id = $1
+	`, seasonID).Scan(&title.ID, &title.WinnerID, &title.Notes, &title.DeclaredAt)
+	switch {
+	case err == nil:
+		input.Title = &title
+	case !errors.Is(err, pgx.ErrNoRows):
+		return nil, fmt.Errorf("store: feed season title: %w", err)
+	}
+
+	out := feed.Build(input)
+	return &out, nil
+}
@@ -0,0 +1,198 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"pickem/backend/internal/models"
+	"pickem/backend/sportsdata"
+)
+
+// providerStatuses maps the status strings SportsData.io reports (lowercased)
+// onto our game statuses. Anything not listed is treated as unknown rather
+// than guessed at.
+var providerStatuses = map[string]string{
+	"scheduled":    models.GameStatusScheduled,
+	"delayed":      models.GameStatusScheduled,
+	"inprogress":   models.GameStatusInProgress,
+	"in-progress":  models.GameStatusInProgress,
+	"in progress":  models.GameStatusInProgress,
+	"final":        models.GameStatusFinal,
+	"f/ot":         models.GameStatusFinal,
+	"final/ot":     models.GameStatusFinal,
+	"postponed":    models.GameStatusPostponed,
+	"suspended":    models.GameStatusSuspended,
+	"canceled":     models.GameStatusCanceled,
+	"cancelled":    models.GameStatusCanceled,
+	"notnecessary": models.GameStatusCanceled,
+	"forfeit":      models.GameStatusForfeit,
+}
+
+// gameStatusTransitions lists the statuses a game may move to from each
+// status during a sync. Final, forfeit and canceled games are locked; only
+// UnlockGame moves them out again.
+var gameStatusTransitions = map[string][]string{
+	models.GameStatusScheduled: {
+		models.GameStatusInProgress,
+		models.GameStatusFinal,
+		models.GameStatusPostponed,
+		models.GameStatusSuspended,
+		models.GameStatusCanceled,
+		models.GameStatusForfeit,
+	},
+	models.GameStatusInProgress: {
+		models.GameStatusFinal,
+		models.GameStatusPostponed,
+		models.GameStatusSuspended,
+		models.GameStatusCanceled,
+		models.GameStatusForfeit,
+	},
+	models.GameStatusPostponed: {
+		models.GameStatusScheduled,
+		models.GameStatusInProgress,
+		models.GameStatusFinal,
+		models.GameStatusSuspended,
+		models.GameStatusCanceled,
+		models.GameStatusForfeit,
+	},
+	models.GameStatusSuspended: {
+		models.GameStatusScheduled,
+		models.GameStatusInProgress,
+		models.GameStatusFinal,
+		models.GameStatusPostponed,
+		models.GameStatusCanceled,
+		models.GameStatusForfeit,
+	},
+	models.GameStatusFinal:    {},
+	models.GameStatusForfeit:  {},
+	models.GameStatusCanceled: {},
+}
+
+// normalizeGameStatus converts a provider status into one of our statuses.
+// The second result is false when the status string was not recognised; the
+// provider's closed flags still mark such games final.
+func normalizeGameStatus(status string, isClosed bool, isOver bool) (string, bool) {
+	normalized := strings.ToLower(strings.TrimSpace(status))
+	if mapped, ok := providerStatuses[normalized]; ok {
+		// SportsData also closes postponed and canceled games, so an explicit
+		// status always wins over the flags.
+		if mapped == models.GameStatusScheduled || mapped == models.GameStatusInProgress {
+			if isOver || isClosed {
+				return models.GameStatusFinal, true
+			}
+		}
+		return mapped, true
+	}
+	if isOver || isClosed {
+		return models.GameStatusFinal, true
+	}
+	return models.GameStatusScheduled, false
+}
+
+// nextGameStatus applies a provider status to the stored one. Unknown provider
+// statuses and transitions the state machine does not allow keep the stored
+// status.
+func nextGameStatus(current, next string, known bool) string {
+	if !known || current == next {
+		return current
+	}
+	allowed, ok := gameStatusTransitions[current]
+	if !ok {
+		// Rows written before the state machine existed may carry anything;
+		// let the provider repair them.
+		return next
+	}
+	for _, candidate := range allowed {
+		if candidate == next {
+			return next
+		}
+	}
+	return current
+}
+
+func isLockedStatus(status string) bool {
+	transitions, ok := gameStatusTransitions[status]
+	return ok && len(transitions) == 0
+}
+
+// UnlockGame undoes a mistaken final (or forfeit/canceled) result. The winner
+// and any commissioner override are cleared and the status falls back to the last provider status if that is
+// still open, otherwise to scheduled or in-progress based on kickoff, so the
+// next sync can move the game forward again.
+func (s *Store) UnlockGame(ctx context.Context, seasonWeekID, gameKey string) (*models.Game, error) {
+	gameKey = strings.TrimSpace(gameKey)
+	if gameKey == "" {
+		return nil, fmt.Errorf("store: unlock game requires game key")
+	}
+
+	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("store: unlock game begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var (
+		kickoff *time.Time
+		payload []byte
+	)
+	err = tx.QueryRow(ctx, `
+		select kickoff, sportsdata_payload
+		from games
+		where season_week_id = $1 and game_key = $2
+		for update
+	`, seasonWeekID, gameKey).Scan(&kickoff, &payload)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrGameNotFound
+		}
+		return nil, fmt.Errorf("store: unlock game: %w", err)
+	}
+
+	status := unlockedStatus(kickoff, payload, time.Now())
+
+	row := tx.QueryRow(ctx, `
+		update games
+		set
+			status = $3,
+			winner = null,
+			result_source = 'provider',
+			result_reason = null,
+			result_updated_at = now(),
+			updated_at = now()
+		where season_week_id = $1 and game_key = $2
+		returning `+returnedGameColumns, seasonWeekID, gameKey, status)
+
+	game, err := scanReturnedGame(row, gameKey)
+	if err != nil {
+		if errors.Is(err, ErrGameNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("store: unlock game: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("store: unlock game commit: %w", err)
+	}
+	return game, nil
+}
+
+func unlockedStatus(kickoff *time.Time, payload []byte, now time.Time) string {
+	if len(payload) > 0 {
+		var snap sportsdata.GameSnapshot
+		if err := json.Unmarshal(payload, &snap); err == nil {
+			if status, known := normalizeGameStatus(snap.Status, snap.IsClosed, snap.IsOver); known && !isLockedStatus(status) {
+				return status
+			}
+		}
+	}
+	if kickoff != nil && !kickoff.After(now) {
+		return models.GameStatusInProgress
+	}
+	return models.GameStatusScheduled
+}
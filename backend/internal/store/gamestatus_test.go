@@ -0,0 +1,133 @@
+package store
+
+import (
+	"testing"
+	"time"
+
+	"pickem/backend/internal/models"
+)
+
+func TestNormalizeGameStatus(t *testing.T) {
+	tests := []struct {
+		status    string
+		closed    bool
+		over      bool
+		want      string
+		wantKnown bool
+	}{
+		{"Scheduled", false, false, models.GameStatusScheduled, true},
+		{"Delayed", false, false, models.GameStatusScheduled, true},
+		{"InProgress", false, false, models.GameStatusInProgress, true},
+		{"in progress", false, false, models.GameStatusInProgress, true},
+		{"Final", true, true, models.GameStatusFinal, true},
+		{"F/OT", true, true, models.GameStatusFinal, true},
+		{" final/ot ", false, false, models.GameStatusFinal, true},
+		{"Postponed", false, false, models.GameStatusPostponed, true},
+		{"Suspended", false, false, models.GameStatusSuspended, true},
+		{"Canceled", false, false, models.GameStatusCanceled, true},
+		{"Cancelled", false, false, models.GameStatusCanceled, true},
+		{"NotNecessary", false, false, models.GameStatusCanceled, true},
+		{"Forfeit", false, false, models.GameStatusForfeit, true},
+
+		// The closed flags finish games the status has not caught up with,
+		// but never override a postponement or cancellation.
+		{"Scheduled", true, false, models.GameStatusFinal, true},
+		{"InProgress", false, true, models.GameStatusFinal, true},
+		{"Postponed", true, true, models.GameStatusPostponed, true},
+		{"Canceled", true, true, models.GameStatusCanceled, true},
+
+		// Unknown strings are reported as such.
+		{"foo", false, false, models.GameStatusScheduled, false},
+		{"f", false, false, models.GameStatusScheduled, false},
+		{"", false, false, models.GameStatusScheduled, false},
+		{"foo", true, false, models.GameStatusFinal, true},
+	}
+	for _, tt := range tests {
+		got, known := normalizeGameStatus(tt.status, tt.closed, tt.over)
+		if got != tt.want || known != tt.wantKnown {
+			t.Errorf("normalizeGameStatus(%q, closed=%v, over=%v) = %q, %v; want %q, %v",
+				tt.status, tt.closed, tt.over, got, known, tt.want, tt.wantKnown)
+		}
+	}
+}
+
+func TestNextGameStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		current string
+		next    string
+		known   bool
+		want    string
+	}{
+		{"kickoff", models.GameStatusScheduled, models.GameStatusInProgress, true, models.GameStatusInProgress},
+		{"straight to final", models.GameStatusScheduled, models.GameStatusFinal, true, models.GameStatusFinal},
+		{"game ends", models.GameStatusInProgress, models.GameStatusFinal, true, models.GameStatusFinal},
+		{"postponed game rescheduled", models.GameStatusPostponed, models.GameStatusScheduled, true, models.GameStatusScheduled},
+		{"suspended game resumes", models.GameStatusSuspended, models.GameStatusInProgress, true, models.GameStatusInProgress},
+		{"unchanged", models.GameStatusInProgress, models.GameStatusInProgress, true, models.GameStatusInProgress},
+		{"in progress cannot go back", models.GameStatusInProgress, models.GameStatusScheduled, true, models.GameStatusInProgress},
+		{"final is locked", models.GameStatusFinal, models.GameStatusInProgress, true, models.GameStatusFinal},
+		{"final stays final over scheduled", models.GameStatusFinal, models.GameStatusScheduled, true, models.GameStatusFinal},
+		{"forfeit is locked", models.GameStatusForfeit, models.GameStatusFinal, true, models.GameStatusForfeit},
+		{"canceled is locked", models.GameStatusCanceled, models.GameStatusScheduled, true, models.GameStatusCanceled},
+		{"unknown provider status", models.GameStatusInProgress, models.GameStatusScheduled, false, models.GameStatusInProgress},
+		{"legacy stored status is repaired", "closed", models.GameStatusFinal, true, models.GameStatusFinal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nextGameStatus(tt.current, tt.next, tt.known); got != tt.want {
+				t.Errorf("nextGameStatus(%q, %q, %v) = %q, want %q", tt.current, tt.next, tt.known, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsLockedStatus(t *testing.T) {
+	for status, want := range map[string]bool{
+		models.GameStatusScheduled:  false,
+		models.GameStatusInProgress: false,
+		models.GameStatusPostponed:  false,
+		models.GameStatusSuspended:  false,
+		models.GameStatusFinal:      true,
+		models.GameStatusForfeit:    true,
+		models.GameStatusCanceled:   true,
+		"closed":                    false,
+	} {
+		if got := isLockedStatus(status); got != want {
+			t.Errorf("isLockedStatus(%q) = %v, want %v", status, got, want)
+		}
+	}
+}
+
+func TestUnlockedStatus(t *testing.T) {
+	now := time.Date(2024, 9, 8, 18, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	tests := []struct {
+		name    string
+		kickoff *time.Time
+		payload string
+		want    string
+	}{
+		{"no payload before kickoff", &future, "", models.GameStatusScheduled},
+		{"no payload after kickoff", &past, "", models.GameStatusInProgress},
+		{"no payload at kickoff", &now, "", models.GameStatusInProgress},
+		{"no payload or kickoff", nil, "", models.GameStatusScheduled},
+		{"provider still in progress", &past, `{"status":"InProgress"}`, models.GameStatusInProgress},
+		{"provider postponed", &past, `{"status":"Postponed"}`, models.GameStatusPostponed},
+		{"provider scheduled", &future, `{"status":"Scheduled"}`, models.GameStatusScheduled},
+		{"provider final falls back to kickoff", &past, `{"status":"Final","isClosed":true,"isOver":true}`, models.GameStatusInProgress},
+		{"provider closed falls back to kickoff", &future, `{"status":"Scheduled","isClosed":true}`, models.GameStatusScheduled},
+		{"provider canceled falls back to kickoff", &past, `{"status":"Canceled"}`, models.GameStatusInProgress},
+		{"unknown provider status", &future, `{"status":"foo"}`, models.GameStatusScheduled},
+		{"unreadable payload", &past, `not json`, models.GameStatusInProgress},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := unlockedStatus(tt.kickoff, []byte(tt.payload), now); got != tt.want {
+				t.Errorf("unlockedStatus() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
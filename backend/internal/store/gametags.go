@@ -0,0 +1,105 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"pickem/backend/internal/gametags"
+	"pickem/backend/internal/models"
+)
+
+// RefreshGameTags recomputes the week's game tags from its current results
+// and replaces the stored set.
+func (s *Store) RefreshGameTags(ctx context.Context, seasonWeekID string) error {
+	var seasonID string
+	if err := s.pool.QueryRow(ctx, `
+		select season_id
+		from season_weeks
+		where id = $1
+	`, seasonWeekID).Scan(&seasonID); err != nil {
+		return fmt.Errorf("store: game tags week: %w", err)
+	}
+
+	settings, err := s.getSeasonSettings(ctx, seasonID)
+	if err != nil {
+		return err
+	}
+
+	games, err := s.listGamesWithPicks(ctx, seasonWeekID)
+	if err != nil {
+		return err
+	}
+
+	weekResult, err := s.getWeekResult(ctx, seasonWeekID)
+	if err != nil {
+		return err
+	}
+
+	week := gametags.Week{
+		Games:     games,
+		TiePoints: tiePointValue(settings.TieScoring),
+	}
+	if weekResult != nil {
+		week.WinnerID = weekResult.WinnerMemberID
+	}
+	if game := tieBreakerGame(games); game != nil {
+		week.TieBreakerGameID = game.ID
+	}
+	tags := gametags.Compute(week)
+
+	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("store: game tags begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `delete from game_tags where season_week_id = $1`, seasonWeekID); err != nil {
+		return fmt.Errorf("store: clear game tags: %w", err)
+	}
+	for _, tag := range tags {
+		if _, err := tx.Exec(ctx, `
+			insert into game_tags (season_week_id, game_id, tag, value)
+			values ($1, $2, $3, $4)
+		`, seasonWeekID, tag.GameID, tag.Tag, tag.Value); err != nil {
+			return fmt.Errorf("store: insert game tag %s: %w", tag.Tag, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("store: commit game tags: %w", err)
+	}
+	return nil
+}
+
+// attachGameTags fills in each game's stored tags.
+func (s *Store) attachGameTags(ctx context.Context, seasonWeekID string, games []models.Game) error {
+	rows, err := s.pool.Query(ctx, `
+		select game_id, tag
+		from game_tags
+		where season_week_id = $1
+		order by tag asc
+	`, seasonWeekID)
+	if err != nil {
+		return fmt.Errorf("store: list game tags: %w", err)
+	}
+	defer rows.Close()
+
+	tags := map[string][]string{}
+	for rows.Next() {
+		var gameID, tag string
+		if err := rows.Scan(&gameID, &tag); err != nil {
+			return fmt.Errorf("store: scan game tag: %w", err)
+		}
+		tags[gameID] = append(tags[gameID], tag)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for i := range games {
+		games[i].Tags = tags[games[i].ID]
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"pickem/backend/internal/models"
+	"pickem/backend/internal/stats"
+)
+
+// GetHallOfFame totals titles, weeks won, correct picks, best weeks and
+// streaks for every member across every season.
+func (s *Store) GetHallOfFame(ctx context.Context) (*models.HallOfFame, error) {
+	rows, err := s.pool.Query(ctx, `
+		select id, name
+		from family_members
+		order by name asc
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("store: list members: %w", err)
+	}
+	defer rows.Close()
+
+	members := []stats.MemberInfo{}
+	for rows.Next() {
+		var member stats.MemberInfo
+		if err := rows.Scan(&member.ID, &member.Name); err != nil {
+			return nil, fmt.Errorf("store: scan member: %w", err)
+		}
+		members = append(members, member)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	seasons, err := s.ListSeasons(ctx)
+	if err != nil {
+		return nil, err
+	}
+	history, err := s.loadSeasonHistory(ctx, seasons)
+	if err != nil {
+		return nil, err
+	}
+
+	result := stats.HallOfFame(members, history)
+	return &result, nil
+}
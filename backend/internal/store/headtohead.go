@@ -0,0 +1,149 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"pickem/backend/internal/models"
+	"pickem/backend/internal/stats"
+)
+
+var ErrSameMember = errors.New("store: cannot compare a member with themselves")
+
+// GetHeadToHead compares two members over one season, or over every season
+// when seasonID is empty.
+func (s *Store) GetHeadToHead(ctx context.Context, seasonID, memberID, opponentID string) (*models.HeadToHead, error) {
+	if memberID == opponentID {
+		return nil, ErrSameMember
+	}
+
+	memberName, err := s.getMemberName(ctx, memberID)
+	if err != nil {
+		return nil, err
+	}
+	opponentName, err := s.getMemberName(ctx, opponentID)
+	if err != nil {
+		return nil, err
+	}
+
+	var seasons []models.Season
+	if seasonID != "" {
+		season, err := s.getSeason(ctx, seasonID)
+		if err != nil {
+			return nil, err
+		}
+		seasons = []models.Season{*season}
+	} else {
+		seasons, err = s.ListSeasons(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	history, err := s.loadSeasonHistory(ctx, seasons)
+	if err != nil {
+		return nil, err
+	}
+
+	result := stats.HeadToHead(memberID, opponentID, history)
+	result.SeasonID = seasonID
+	result.Member.Name = memberName
+	result.Opponent.Name = opponentName
+	return &result, nil
+}
+
+func (s *Store) getMemberName(ctx context.Context, memberID string) (string, error) {
+	var name string
+	err := s.pool.QueryRow(ctx, `
+		select name
+		from family_members
+		where id::text = $1
+	`, memberID).Scan(&name)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", ErrMemberNotFound
+		}
+		return "", fmt.Errorf("store: get member: %w", err)
+	}
+	return name, nil
+}
+
+// loadSeasonHistory loads the games, tie scoring, declared week winners and
+// title winner for each season, oldest first.
+func (s *Store) loadSeasonHistory(ctx context.Context, seasons []models.Season) ([]stats.Season, error) {
+	history := make([]stats.Season, 0, len(seasons))
+	for i := len(seasons) - 1; i >= 0; i-- {
+		season := seasons[i]
+
+		settings, err := s.getSeasonSettings(ctx, season.ID)
+		if err != nil {
+			return nil, err
+		}
+		weeks, err := s.listSeasonGames(ctx, season.ID)
+		if err != nil {
+			return nil, err
+		}
+		winners, err := s.weekWinners(ctx, season.ID)
+		if err != nil {
+			return nil, err
+		}
+		champion, err := s.seasonTitleWinner(ctx, season.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		history = append(history, stats.Season{
+			ID:          season.ID,
+			Label:       season.Label,
+			TiePoints:   tiePointValue(settings.TieScoring),
+			Weeks:       weeks,
+			WeekWinners: winners,
+			TitleWinner: champion,
+		})
+	}
+	return history, nil
+}
+
+func (s *Store) weekWinners(ctx context.Context, seasonID string) (map[int]string, error) {
+	rows, err := s.pool.Query(ctx, `
+		select w.number, wr.winner_member_id
+		from week_results wr
+			join season_weeks w on w.id = wr.season_week_id
+		where w.season_id = $1
+			and wr.winner_member_id is not null
+	`, seasonID)
+	if err != nil {
+		return nil, fmt.Errorf("store: week winners: %w", err)
+	}
+	defer rows.Close()
+
+	winners := map[int]string{}
+	for rows.Next() {
+		var weekNumber int
+		var memberID string
+		if err := rows.Scan(&weekNumber, &memberID); err != nil {
+			return nil, fmt.Errorf("store: week winner scan: %w", err)
+		}
+		winners[weekNumber] = memberID
+	}
+	return winners, rows.Err()
+}
+
+func (s *Store) seasonTitleWinner(ctx context.Context, seasonID string) (string, error) {
+	var winner *string
+	err := s.pool.QueryRow(ctx, `
+		select winner_member_id
+		from season_titles
+		where season_id = $1
+	`, seasonID).Scan(&winner)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", nil
+		}
+		return "", fmt.Errorf("store: season title: %w", err)
+	}
+	return derefString(winner), nil
+}
@@ -0,0 +1,79 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	"pickem/backend/internal/models"
+)
+
+// proxyMember returns enteredBy when someone other than the member entered
+// the pick, and "" otherwise.
+func proxyMember(memberID, enteredBy string) string {
+	enteredBy = strings.TrimSpace(enteredBy)
+	if enteredBy == memberID {
+		return ""
+	}
+	return enteredBy
+}
+
+func recordPickHistory(ctx context.Context, tx pgx.Tx, memberID, gameID, action, chosenSide, enteredBy string) error {
+	if _, err := tx.Exec(ctx, `
+		insert into pick_history (member_id, game_id, action, chosen_side, entered_by)
+		values ($1, $2, $3, $4, $5)
+	`, memberID, gameID, action, nullIfEmpty(chosenSide), nullIfEmpty(enteredBy)); err != nil {
+		return fmt.Errorf("store: record pick history: %w", err)
+	}
+	return nil
+}
+
+// ListPickHistory returns every pick change in the week, oldest first,
+// optionally limited to one member.
+func (s *Store) ListPickHistory(ctx context.Context, seasonID string, weekNumber int, memberID string) ([]models.PickHistoryEntry, error) {
+	week, err := s.getWeekByNumber(ctx, seasonID, weekNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.pool.Query(ctx, `
+		select h.id, h.member_id, m.name, g.game_key, h.action,
+			coalesce(h.chosen_side, ''),
+			coalesce(h.entered_by::text, ''),
+			coalesce(e.name, ''),
+			h.created_at
+		from pick_history h
+			join games g on g.id = h.game_id
+			join family_members m on m.id = h.member_id
+			left join family_members e on e.id = h.entered_by
+		where g.season_week_id = $1
+			and ($2 = '' or h.member_id::text = $2)
+		order by h.created_at asc, h.id asc
+	`, week.ID, strings.TrimSpace(memberID))
+	if err != nil {
+		return nil, fmt.Errorf("store: list pick history: %w", err)
+	}
+	defer rows.Close()
+
+	history := []models.PickHistoryEntry{}
+	for rows.Next() {
+		var entry models.PickHistoryEntry
+		if err := rows.Scan(
+			&entry.ID,
+			&entry.MemberID,
+			&entry.MemberName,
+			&entry.GameKey,
+			&entry.Action,
+			&entry.ChosenSide,
+			&entry.EnteredBy,
+			&entry.EnteredByName,
+			&entry.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("store: scan pick history: %w", err)
+		}
+		history = append(history, entry)
+	}
+	return history, rows.Err()
+}
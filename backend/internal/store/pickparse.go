@@ -0,0 +1,68 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"pickem/backend/internal/models"
+	"pickem/backend/internal/pickparse"
+)
+
+// ParsePickMessage reads a member's free-text picks against the week's
+// games. Nothing is saved; CommitPickMessage saves a clean result.
+func (s *Store) ParsePickMessage(ctx context.Context, seasonID string, weekNumber int, memberID, message string) (*models.PickParseResult, error) {
+	if _, err := s.getMemberName(ctx, memberID); err != nil {
+		return nil, err
+	}
+
+	week, err := s.getWeekByNumber(ctx, seasonID, weekNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	games, err := s.listGamesWithPicks(ctx, week.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := pickparse.Parse(message, games)
+	result.MemberID = memberID
+	return &result, nil
+}
+
+// CommitPickMessage saves every pick in a parsed message, and its
+// tie-breaker, in one transaction: either the whole message is saved or
+// none of it is. Picks follow UpsertPick, including ErrPickLocked.
+func (s *Store) CommitPickMessage(ctx context.Context, seasonID string, weekNumber int, result *models.PickParseResult, enteredBy string) ([]models.GamePick, error) {
+	week, err := s.getWeekByNumber(ctx, seasonID, weekNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("store: commit pick message begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	picks := make([]models.GamePick, 0, len(result.Picks))
+	for _, parsed := range result.Picks {
+		pick, err := upsertPick(ctx, tx, result.MemberID, parsed.GameKey, parsed.Side, enteredBy)
+		if err != nil {
+			return nil, err
+		}
+		picks = append(picks, *pick)
+	}
+	if result.TieBreaker != nil {
+		if err := upsertTieBreaker(ctx, tx, result.MemberID, week.ID, *result.TieBreaker); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("store: commit pick message commit: %w", err)
+	}
+	return picks, nil
+}
@@ -0,0 +1,176 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+
+	"pickem/backend/internal/models"
+	"pickem/backend/internal/odds"
+	"pickem/backend/internal/simulation"
+)
+
+// GetWinProbabilities simulates the rest of the given week and season.
+func (s *Store) GetWinProbabilities(ctx context.Context, seasonID string, weekNumber, iterations int, rng *rand.Rand) (*models.ProbabilityReport, error) {
+	if _, err := s.getSeason(ctx, seasonID); err != nil {
+		return nil, err
+	}
+
+	week, err := s.getWeekByNumber(ctx, seasonID, weekNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := s.getSeasonSettings(ctx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := s.loadMemberWeeks(ctx, seasonID, settings.TieScoring)
+	if err != nil {
+		return nil, err
+	}
+
+	games, err := s.listGamesWithPicks(ctx, week.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	weekResult, err := s.getWeekResult(ctx, week.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	guesses, err := s.weekTieBreakers(ctx, week.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	remainingWeeks, remainingGames, err := s.seasonRemaining(ctx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	weekInput := simulation.Week{
+		Current:    map[string]float64{},
+		TiePoints:  tiePointValue(settings.TieScoring),
+		TieBreaker: tieBreakerFor(games, guesses),
+	}
+	if weekResult != nil {
+		weekInput.DeclaredWinner = weekResult.WinnerMemberID
+	}
+	for _, game := range games {
+		if isGradedStatus(game.Status) || game.Status == models.GameStatusCanceled {
+			continue
+		}
+		probability, _ := odds.HomeWinProbability(game)
+		entry := simulation.Game{GameKey: game.GameKey, HomeWinProbability: probability}
+		for _, pick := range game.Picks {
+			entry.Picks = append(entry.Picks, simulation.Pick{MemberID: pick.MemberID, Side: pick.ChosenSide})
+		}
+		weekInput.Remaining = append(weekInput.Remaining, entry)
+	}
+
+	seasonInput := simulation.Season{
+		Rule:        settings.StandingsRule,
+		WeeksWon:    map[string]int{},
+		Points:      map[string]float64{},
+		Graded:      map[string]int{},
+		FutureWeeks: remainingWeeks,
+		FutureGames: remainingGames - len(weekInput.Remaining),
+	}
+	if weekResult == nil {
+		seasonInput.FutureWeeks--
+	}
+	if seasonInput.FutureWeeks < 0 {
+		seasonInput.FutureWeeks = 0
+	}
+	if seasonInput.FutureGames < 0 {
+		seasonInput.FutureGames = 0
+	}
+
+	for _, member := range members {
+		weekInput.MemberIDs = append(weekInput.MemberIDs, member.MemberID)
+		weekInput.Current[member.MemberID] = member.Records[week.Number].Points
+		seasonInput.WeeksWon[member.MemberID] = len(member.WeeksWon)
+		for _, record := range member.Records {
+			seasonInput.Points[member.MemberID] += record.Points
+			seasonInput.Graded[member.MemberID] += record.Wins + record.Losses + record.Ties
+		}
+	}
+
+	result := simulation.Run(weekInput, seasonInput, iterations, rng)
+
+	report := &models.ProbabilityReport{
+		SeasonID:   seasonID,
+		WeekNumber: week.Number,
+		Iterations: result.Iterations,
+		SeasonRule: settings.StandingsRule,
+		Members:    []models.MemberProbability{},
+	}
+	for _, member := range members {
+		report.Members = append(report.Members, models.MemberProbability{
+			MemberID: member.MemberID,
+			Week:     result.Week[member.MemberID],
+			Season:   result.Season[member.MemberID],
+		})
+	}
+	return report, nil
+}
+
+// tieBreakerFor sets up the simulated tie-breaker from the week's last game.
+func tieBreakerFor(games []models.Game, guesses map[string]int) simulation.TieBreaker {
+	tieBreaker := simulation.TieBreaker{Guesses: guesses}
+
+	last := tieBreakerGame(games)
+	if last == nil {
+		return tieBreaker
+	}
+
+	if isGradedStatus(last.Status) && last.HomeScore != nil && last.AwayScore != nil {
+		total := *last.HomeScore + *last.AwayScore
+		tieBreaker.ActualTotal = &total
+	}
+	if last.OverUnder != nil {
+		tieBreaker.ExpectedTotal = *last.OverUnder
+	}
+	return tieBreaker
+}
+
+// tieBreakerGame is the week's last game to kick off, whose combined score
+// the tie-breaker guesses are measured against.
+func tieBreakerGame(games []models.Game) *models.Game {
+	var last *models.Game
+	for i := range games {
+		if games[i].Kickoff == nil || games[i].Status == models.GameStatusCanceled {
+			continue
+		}
+		if last == nil || games[i].Kickoff.After(*last.Kickoff) {
+			last = &games[i]
+		}
+	}
+	return last
+}
+
+func (s *Store) weekTieBreakers(ctx context.Context, seasonWeekID string) (map[string]int, error) {
+	rows, err := s.pool.Query(ctx, `
+		select member_id, points
+		from tie_breakers
+		where season_week_id = $1
+	`, seasonWeekID)
+	if err != nil {
+		return nil, fmt.Errorf("store: week tie breakers: %w", err)
+	}
+	defer rows.Close()
+
+	guesses := map[string]int{}
+	for rows.Next() {
+		var memberID string
+		var points int
+		if err := rows.Scan(&memberID, &points); err != nil {
+			return nil, fmt.Errorf("store: week tie breaker scan: %w", err)
+		}
+		guesses[memberID] = points
+	}
+	return guesses, rows.Err()
+}
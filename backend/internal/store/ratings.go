@@ -0,0 +1,165 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"pickem/backend/internal/models"
+	"pickem/backend/internal/rating"
+)
+
+// ratingsLockKey names the advisory lock that serialises rating rebuilds
+// across requests, background jobs and other API instances.
+const ratingsLockKey = 7_246_013
+
+// RecomputeRatings replays every season's final weeks and replaces the
+// stored rating history. Any result change can ripple into later weeks, so
+// the whole history is rebuilt rather than patched. Each rebuild holds a
+// transaction-scoped advisory lock from before it reads until it commits,
+// so concurrent rebuilds queue instead of racing on the delete and insert.
+func (s *Store) RecomputeRatings(ctx context.Context) error {
+	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("store: ratings begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `select pg_advisory_xact_lock($1)`, ratingsLockKey); err != nil {
+		return fmt.Errorf("store: lock ratings: %w", err)
+	}
+
+	seasons, err := s.ListSeasons(ctx)
+	if err != nil {
+		return err
+	}
+	history, err := s.loadSeasonHistory(ctx, seasons)
+	if err != nil {
+		return err
+	}
+	entries := rating.Compute(history)
+
+	if _, err := tx.Exec(ctx, `delete from member_ratings`); err != nil {
+		return fmt.Errorf("store: clear ratings: %w", err)
+	}
+
+	batch := &pgx.Batch{}
+	for _, entry := range entries {
+		batch.Queue(`
+			insert into member_ratings (season_week_id, member_id, rating, change, games)
+			values ($1, $2, $3, $4, $5)
+		`, entry.WeekID, entry.MemberID, entry.Rating, entry.Change, entry.Games)
+	}
+	if batch.Len() > 0 {
+		if err := tx.SendBatch(ctx, batch).Close(); err != nil {
+			return fmt.Errorf("store: insert ratings: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("store: commit ratings: %w", err)
+	}
+	return nil
+}
+
+// GetRatings returns every member's rating history for the season, along
+// with the rating they carried in from earlier seasons.
+func (s *Store) GetRatings(ctx context.Context, seasonID string) (*models.RatingReport, error) {
+	if _, err := s.getSeason(ctx, seasonID); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.pool.Query(ctx, `
+		select m.id, m.name, coalesce((
+			select r.rating
+			from member_ratings r
+				join season_weeks w on w.id = r.season_week_id
+				join seasons s on s.id = w.season_id
+			where r.member_id = m.id
+				and (s.season_year, s.created_at) < (cur.season_year, cur.created_at)
+			order by s.season_year desc, s.created_at desc, w.number desc
+			limit 1
+		), $2) as start_rating
+		from family_members m
+			cross join seasons cur
+		where cur.id = $1
+		order by m.name asc
+	`, seasonID, rating.Initial)
+	if err != nil {
+		return nil, fmt.Errorf("store: list member ratings: %w", err)
+	}
+	defer rows.Close()
+
+	report := &models.RatingReport{SeasonID: seasonID, Members: []models.MemberRating{}}
+	memberIndex := map[string]int{}
+	for rows.Next() {
+		member := models.MemberRating{History: []models.RatingPoint{}}
+		if err := rows.Scan(&member.MemberID, &member.Name, &member.StartRating); err != nil {
+			return nil, fmt.Errorf("store: scan member rating: %w", err)
+		}
+		member.Rating = member.StartRating
+		memberIndex[member.MemberID] = len(report.Members)
+		report.Members = append(report.Members, member)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	historyRows, err := s.pool.Query(ctx, `
+		select r.member_id, w.number, r.rating, r.change, r.games
+		from member_ratings r
+			join season_weeks w on w.id = r.season_week_id
+		where w.season_id = $1
+		order by w.number asc
+	`, seasonID)
+	if err != nil {
+		return nil, fmt.Errorf("store: rating history: %w", err)
+	}
+	defer historyRows.Close()
+
+	for historyRows.Next() {
+		var memberID string
+		var point models.RatingPoint
+		if err := historyRows.Scan(&memberID, &point.WeekNumber, &point.Rating, &point.Change, &point.Games); err != nil {
+			return nil, fmt.Errorf("store: rating history scan: %w", err)
+		}
+		if idx, ok := memberIndex[memberID]; ok {
+			report.Members[idx].History = append(report.Members[idx].History, point)
+			report.Members[idx].Rating = point.Rating
+		}
+	}
+	return report, historyRows.Err()
+}
+
+// populateRatings sets each member's rating as of the active week.
+func (s *Store) populateRatings(ctx context.Context, seasonID string, weekNumber int, memberIndex map[string]int, members []models.Member) error {
+	rows, err := s.pool.Query(ctx, `
+		select distinct on (r.member_id) r.member_id, r.rating
+		from member_ratings r
+			join season_weeks w on w.id = r.season_week_id
+			join seasons s on s.id = w.season_id
+			cross join seasons cur
+		where cur.id = $1
+			and ((s.season_year, s.created_at) < (cur.season_year, cur.created_at)
+				or (s.id = cur.id and w.number <= $2))
+		order by r.member_id, s.season_year desc, s.created_at desc, w.number desc
+	`, seasonID, weekNumber)
+	if err != nil {
+		return fmt.Errorf("store: member ratings: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var memberID string
+		var value float64
+		if err := rows.Scan(&memberID, &value); err != nil {
+			return fmt.Errorf("store: member rating scan: %w", err)
+		}
+		if idx, ok := memberIndex[memberID]; ok {
+			current := value
+			members[idx].Rating = &current
+		}
+	}
+	return rows.Err()
+}
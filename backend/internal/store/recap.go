@@ -0,0 +1,79 @@
+package store
+
+import (
+	"context"
+
+	"pickem/backend/internal/models"
+	"pickem/backend/internal/recap"
+)
+
+// GetWeekRecap gathers the week's results, picks and standings into a
+// recap.
+func (s *Store) GetWeekRecap(ctx context.Context, seasonID string, weekNumber int) (*models.WeekRecap, error) {
+	season, err := s.getSeason(ctx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	week, err := s.getWeekByNumber(ctx, seasonID, weekNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := s.getSeasonSettings(ctx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := s.loadMemberWeeks(ctx, seasonID, settings.TieScoring)
+	if err != nil {
+		return nil, err
+	}
+
+	games, err := s.listGamesWithPicks(ctx, week.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.attachGameTags(ctx, week.ID, games); err != nil {
+		return nil, err
+	}
+
+	weekResult, err := s.getWeekResult(ctx, week.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	guesses, err := s.weekTieBreakers(ctx, week.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	standings, err := s.GetStandings(ctx, seasonID, "", week.Number)
+	if err != nil {
+		return nil, err
+	}
+
+	input := recap.Input{
+		Season:         *season,
+		Week:           *week,
+		Games:          games,
+		Result:         weekResult,
+		Members:        make([]models.RecapMember, 0, len(members)),
+		TieBreakerGame: tieBreakerGame(games),
+		Standings:      standings,
+	}
+	for _, member := range members {
+		entry := models.RecapMember{
+			MemberID: member.MemberID,
+			Name:     member.Name,
+			Record:   member.Records[week.Number],
+		}
+		if guess, ok := guesses[member.MemberID]; ok {
+			entry.TieBreaker = &guess
+		}
+		input.Members = append(input.Members, entry)
+	}
+
+	result := recap.Build(input)
+	return &result, nil
+}
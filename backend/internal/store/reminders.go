@@ -0,0 +1,89 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"pickem/backend/internal/models"
+)
+
+// ListMissingPicks returns the members who have not picked every game that
+// is still open (scheduled and not yet kicked off) in the week.
+func (s *Store) ListMissingPicks(ctx context.Context, seasonWeekID string) ([]models.MissingPicks, error) {
+	rows, err := s.pool.Query(ctx, `
+		with open_games as (
+			select id
+			from games
+			where season_week_id = $1
+				and status = 'scheduled'
+				and (kickoff is null or kickoff > now())
+		)
+		select m.id, m.name,
+			(select count(*) from open_games) - count(p.id) as missing,
+			(select count(*) from open_games) as open_count
+		from family_members m
+			left join picks p on p.member_id = m.id
+				and p.game_id in (select id from open_games)
+		group by m.id, m.name
+		having (select count(*) from open_games) - count(p.id) > 0
+		order by m.name asc
+	`, seasonWeekID)
+	if err != nil {
+		return nil, fmt.Errorf("store: missing picks: %w", err)
+	}
+	defer rows.Close()
+
+	missing := []models.MissingPicks{}
+	for rows.Next() {
+		var entry models.MissingPicks
+		if err := rows.Scan(&entry.MemberID, &entry.Name, &entry.Missing, &entry.OpenGames); err != nil {
+			return nil, fmt.Errorf("store: scan missing picks: %w", err)
+		}
+		missing = append(missing, entry)
+	}
+	return missing, rows.Err()
+}
+
+// FirstKickoff returns the earliest kickoff among the week's games that are
+// still on, or nil when none has a kickoff yet.
+func (s *Store) FirstKickoff(ctx context.Context, seasonWeekID string) (*time.Time, error) {
+	var kickoff *time.Time
+	if err := s.pool.QueryRow(ctx, `
+		select min(kickoff)
+		from games
+		where season_week_id = $1
+			and status <> 'canceled'
+	`, seasonWeekID).Scan(&kickoff); err != nil {
+		return nil, fmt.Errorf("store: first kickoff: %w", err)
+	}
+	return kickoff, nil
+}
+
+// NotificationSent reports whether a notification of the given kind already
+// went out for the week.
+func (s *Store) NotificationSent(ctx context.Context, seasonWeekID, kind string) (bool, error) {
+	var sent bool
+	if err := s.pool.QueryRow(ctx, `
+		select exists (
+			select 1 from notification_log
+			where season_week_id = $1 and kind = $2
+		)
+	`, seasonWeekID, kind).Scan(&sent); err != nil {
+		return false, fmt.Errorf("store: notification sent: %w", err)
+	}
+	return sent, nil
+}
+
+// RecordNotification logs that a notification went out for the week.
+func (s *Store) RecordNotification(ctx context.Context, seasonWeekID, kind string, recipients int) error {
+	if _, err := s.pool.Exec(ctx, `
+		insert into notification_log (season_week_id, kind, recipients)
+		values ($1, $2, $3)
+		on conflict (season_week_id, kind)
+		do update set recipients = excluded.recipients, sent_at = now()
+	`, seasonWeekID, kind, recipients); err != nil {
+		return fmt.Errorf("store: record notification: %w", err)
+	}
+	return nil
+}
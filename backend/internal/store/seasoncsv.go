@@ -0,0 +1,394 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	"pickem/backend/internal/models"
+	"pickem/backend/internal/nfl"
+	"pickem/backend/internal/seasoncsv"
+)
+
+// ErrImportConflict is returned when an imported sheet clashes with data
+// already stored for a different season.
+var ErrImportConflict = errors.New("store: import conflicts with existing data")
+
+// importReason is recorded on games whose results came from a spreadsheet.
+const importReason = "Imported from CSV"
+
+// ExportSeasonSheet gathers a season's games, picks, tie-breakers, week
+// winners and title into spreadsheet form. Every family member gets a
+// column, whether or not they picked that season.
+func (s *Store) ExportSeasonSheet(ctx context.Context, seasonID string) (*seasoncsv.Sheet, error) {
+	if _, err := s.getSeason(ctx, seasonID); err != nil {
+		return nil, err
+	}
+
+	names, err := s.memberNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sheet := &seasoncsv.Sheet{
+		TieBreakers: map[int]map[string]int{},
+		WeekWinners: map[int]string{},
+	}
+	for _, name := range names {
+		sheet.Members = append(sheet.Members, name)
+	}
+	sort.Strings(sheet.Members)
+
+	weeks, err := s.listSeasonWeeks(ctx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+	for _, week := range weeks {
+		games, err := s.listGamesWithPicks(ctx, week.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, game := range games {
+			row := seasoncsv.Game{
+				Week:      week.Number,
+				GameKey:   game.GameKey,
+				Kickoff:   game.Kickoff,
+				Away:      game.AwayTeam.Code,
+				Home:      game.HomeTeam.Code,
+				AwayScore: game.AwayScore,
+				HomeScore: game.HomeScore,
+				Status:    game.Status,
+				Winner:    game.Winner,
+				Picks:     map[string]string{},
+			}
+			for _, pick := range game.Picks {
+				row.Picks[names[pick.MemberID]] = pick.ChosenSide
+			}
+			sheet.Games = append(sheet.Games, row)
+		}
+	}
+
+	rows, err := s.pool.Query(ctx, `
+		select w.number, t.member_id, t.points
+		from tie_breakers t
+			join season_weeks w on w.id = t.season_week_id
+		where w.season_id = $1
+	`, seasonID)
+	if err != nil {
+		return nil, fmt.Errorf("store: export tie-breakers: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			weekNumber int
+			memberID   string
+			points     int
+		)
+		if err := rows.Scan(&weekNumber, &memberID, &points); err != nil {
+			return nil, fmt.Errorf("store: scan tie-breaker: %w", err)
+		}
+		if sheet.TieBreakers[weekNumber] == nil {
+			sheet.TieBreakers[weekNumber] = map[string]int{}
+		}
+		sheet.TieBreakers[weekNumber][names[memberID]] = points
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("store: export tie-breakers: %w", err)
+	}
+
+	winners, err := s.weekWinners(ctx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+	for weekNumber, memberID := range winners {
+		sheet.WeekWinners[weekNumber] = names[memberID]
+	}
+
+	champion, err := s.seasonTitleWinner(ctx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+	sheet.Champion = names[champion]
+
+	return sheet, nil
+}
+
+// memberNames maps every family member's ID to their name.
+func (s *Store) memberNames(ctx context.Context) (map[string]string, error) {
+	rows, err := s.pool.Query(ctx, `select id, name from family_members`)
+	if err != nil {
+		return nil, fmt.Errorf("store: list members: %w", err)
+	}
+	defer rows.Close()
+
+	names := map[string]string{}
+	for rows.Next() {
+		var id, name string
+		if err := rows.Scan(&id, &name); err != nil {
+			return nil, fmt.Errorf("store: scan member: %w", err)
+		}
+		names[id] = name
+	}
+	return names, rows.Err()
+}
+
+// ImportSeasonSheet loads a validated sheet into the season identified by
+// season.SportsDataSeasonKey, creating the season, its weeks and any
+// members it has not seen before. Rows already present are updated in
+// place, so importing the same sheet twice changes nothing. A game's result
+// is only taken from the sheet, and marked manual, when the game is new or
+// the sheet names a different winner; a blank winner keeps whatever result
+// is stored, so provider syncs go on updating it. Games without a
+// key get one built from the season key, week and teams. With dryRun the
+// import runs in full and is then rolled back, so the report still counts
+// what would change.
+func (s *Store) ImportSeasonSheet(ctx context.Context, season models.Season, sheet *seasoncsv.Sheet, dryRun bool) (*models.SeasonImport, error) {
+	seasonKey := strings.TrimSpace(season.SportsDataSeasonKey)
+	if seasonKey == "" {
+		return nil, fmt.Errorf("store: import needs a season key")
+	}
+
+	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("store: import begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	report := &models.SeasonImport{DryRun: dryRun, Members: len(sheet.Members)}
+
+	err = tx.QueryRow(ctx, `
+		select id, label from seasons where sportsdata_season_key = $1
+	`, seasonKey).Scan(&report.SeasonID, &report.Label)
+	if errors.Is(err, pgx.ErrNoRows) {
+		report.Label = season.Label
+		err = tx.QueryRow(ctx, `
+			insert into seasons (label, season_year, sportsdata_season_key)
+			values ($1, $2, $3)
+			returning id
+		`, season.Label, season.Year, seasonKey).Scan(&report.SeasonID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("store: import season: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `
+		insert into season_settings (season_id, current_week)
+		values ($1, 1)
+		on conflict (season_id) do nothing
+	`, report.SeasonID); err != nil {
+		return nil, fmt.Errorf("store: import season settings: %w", err)
+	}
+
+	memberIDs := map[string]string{}
+	for _, name := range sheet.Members {
+		id, created, err := importMember(ctx, tx, name)
+		if err != nil {
+			return nil, err
+		}
+		memberIDs[name] = id
+		if created {
+			report.MembersCreated++
+		}
+	}
+
+	weekIDs := map[int]string{}
+	weekID := func(number int) (string, error) {
+		if id, ok := weekIDs[number]; ok {
+			return id, nil
+		}
+		id, err := importWeek(ctx, tx, report.SeasonID, number)
+		if err != nil {
+			return "", err
+		}
+		weekIDs[number] = id
+		return id, nil
+	}
+
+	for _, game := range sheet.Games {
+		seasonWeekID, err := weekID(game.Week)
+		if err != nil {
+			return nil, err
+		}
+
+		gameKey := game.GameKey
+		if gameKey == "" {
+			gameKey = fmt.Sprintf("%s-W%d-%s@%s", seasonKey, game.Week, game.Away, game.Home)
+		}
+		var (
+			owner        string
+			storedWinner *string
+		)
+		err = tx.QueryRow(ctx, `
+			select w.season_id, g.winner
+			from games g
+				join season_weeks w on w.id = g.season_week_id
+			where g.game_key = $1
+		`, gameKey).Scan(&owner, &storedWinner)
+		if err == nil && owner != report.SeasonID {
+			return nil, fmt.Errorf("%w: game %s belongs to another season", ErrImportConflict, gameKey)
+		}
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("store: import lookup game %s: %w", gameKey, err)
+		}
+		newGame := errors.Is(err, pgx.ErrNoRows)
+		takeResult := game.Winner != "" && (newGame || game.Winner != derefString(storedWinner))
+
+		homeTeam, err := json.Marshal(nfl.Lookup(game.Home))
+		if err != nil {
+			return nil, fmt.Errorf("store: import marshal home team: %w", err)
+		}
+		awayTeam, err := json.Marshal(nfl.Lookup(game.Away))
+		if err != nil {
+			return nil, fmt.Errorf("store: import marshal away team: %w", err)
+		}
+		source := models.ResultSourceProvider
+		if game.Winner != "" {
+			source = models.ResultSourceManual
+		}
+
+		var gameID string
+		if err := tx.QueryRow(ctx, `
+			insert into games (
+				season_week_id, game_key, kickoff, status, home_team, away_team,
+				home_score, away_score, winner, result_source, result_reason, result_updated_at
+			)
+			values ($1, $2, $3, $4, $5, $6, $7, $8, nullif($9, ''), $10,
+				case when $9 = '' then null else $11 end,
+				case when $9 = '' then null else now() end)
+			on conflict (game_key)
+			do update set
+				season_week_id = excluded.season_week_id,
+				kickoff = excluded.kickoff,
+				home_team = excluded.home_team,
+				away_team = excluded.away_team,
+				status = case when $12 then excluded.status else games.status end,
+				home_score = case when $12 then excluded.home_score else games.home_score end,
+				away_score = case when $12 then excluded.away_score else games.away_score end,
+				winner = case when $12 then excluded.winner else games.winner end,
+				result_source = case when $12 then excluded.result_source else games.result_source end,
+				result_reason = case when $12 then excluded.result_reason else games.result_reason end,
+				result_updated_at = case when $12 then now() else games.result_updated_at end,
+				updated_at = now()
+			returning id
+		`, seasonWeekID, gameKey, game.Kickoff, game.Status, homeTeam, awayTeam,
+			game.HomeScore, game.AwayScore, game.Winner, source, importReason, takeResult).Scan(&gameID); err != nil {
+			return nil, fmt.Errorf("store: import game %s: %w", gameKey, err)
+		}
+		report.Games++
+
+		// A changed side is the sheet's own pick, so it loses any auto or
+		// proxy label; an unchanged one keeps who entered it and how.
+		for member, side := range game.Picks {
+			if _, err := tx.Exec(ctx, `
+				insert into picks (member_id, game_id, chosen_side)
+				values ($1, $2, $3)
+				on conflict (member_id, game_id)
+				do update set chosen_side = excluded.chosen_side, is_auto = false, entered_by = null, updated_at = now()
+				where picks.chosen_side is distinct from excluded.chosen_side
+			`, memberIDs[member], gameID, side); err != nil {
+				return nil, fmt.Errorf("store: import pick for %s: %w", member, err)
+			}
+			report.Picks++
+		}
+	}
+
+	for number, points := range sheet.TieBreakers {
+		seasonWeekID, err := weekID(number)
+		if err != nil {
+			return nil, err
+		}
+		for member, value := range points {
+			if _, err := tx.Exec(ctx, `
+				insert into tie_breakers (member_id, season_week_id, points)
+				values ($1, $2, $3)
+				on conflict (member_id, season_week_id)
+				do update set points = excluded.points
+			`, memberIDs[member], seasonWeekID, value); err != nil {
+				return nil, fmt.Errorf("store: import tie-breaker for %s: %w", member, err)
+			}
+			report.TieBreakers++
+		}
+	}
+
+	for number, member := range sheet.WeekWinners {
+		seasonWeekID, err := weekID(number)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := tx.Exec(ctx, `
+			insert into week_results (season_week_id, winner_member_id, notes)
+			values ($1, $2, $3)
+			on conflict (season_week_id)
+			do update set winner_member_id = excluded.winner_member_id
+		`, seasonWeekID, memberIDs[member], importReason); err != nil {
+			return nil, fmt.Errorf("store: import week %d winner: %w", number, err)
+		}
+		report.WeekWinners++
+	}
+
+	if sheet.Champion != "" {
+		if _, err := tx.Exec(ctx, `
+			insert into season_titles (season_id, winner_member_id, notes)
+			values ($1, $2, $3)
+			on conflict (season_id)
+			do update set winner_member_id = excluded.winner_member_id
+		`, report.SeasonID, memberIDs[sheet.Champion], importReason); err != nil {
+			return nil, fmt.Errorf("store: import season title: %w", err)
+		}
+		report.Champion = sheet.Champion
+	}
+	report.Weeks = len(weekIDs)
+
+	if dryRun {
+		return report, nil
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("store: import commit: %w", err)
+	}
+	return report, nil
+}
+
+// importMember finds a member by name, ignoring case, or adds them.
+func importMember(ctx context.Context, tx pgx.Tx, name string) (string, bool, error) {
+	var id string
+	err := tx.QueryRow(ctx, `
+		select id from family_members where lower(name) = lower($1)
+	`, name).Scan(&id)
+	if err == nil {
+		return id, false, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return "", false, fmt.Errorf("store: import lookup member %q: %w", name, err)
+	}
+	if err := tx.QueryRow(ctx, `
+		insert into family_members (name) values ($1) returning id
+	`, name).Scan(&id); err != nil {
+		return "", false, fmt.Errorf("store: import add member %q: %w", name, err)
+	}
+	return id, true, nil
+}
+
+func importWeek(ctx context.Context, tx pgx.Tx, seasonID string, number int) (string, error) {
+	var id string
+	err := tx.QueryRow(ctx, `
+		select id from season_weeks where season_id = $1 and number = $2
+	`, seasonID, number).Scan(&id)
+	if err == nil {
+		return id, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return "", fmt.Errorf("store: import lookup week %d: %w", number, err)
+	}
+	if err := tx.QueryRow(ctx, `
+		insert into season_weeks (season_id, number, label)
+		values ($1, $2, $3)
+		returning id
+	`, seasonID, number, fmt.Sprintf("Week %d", number)).Scan(&id); err != nil {
+		return "", fmt.Errorf("store: import add week %d: %w", number, err)
+	}
+	return id, nil
+}
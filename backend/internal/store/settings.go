@@ -0,0 +1,108 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	"pickem/backend/internal/autopick"
+	"pickem/backend/internal/models"
+	"pickem/backend/internal/standings"
+)
+
+var ErrInvalidSettings = errors.New("store: invalid season settings")
+
+var validTieScoring = map[string]struct{}{
+	models.TieScoringZero: {},
+	models.TieScoringHalf: {},
+	models.TieScoringWin:  {},
+}
+
+// SeasonSettingsUpdate carries the settings a commissioner wants to change.
+// Nil fields are left untouched.
+type SeasonSettingsUpdate struct {
+	TieScoring    *string
+	StandingsRule *string
+	AutoPickRule  *string
+}
+
+func (s *Store) GetSeasonSettings(ctx context.Context, seasonID string) (*models.SeasonSettings, error) {
+	if _, err := s.getSeason(ctx, seasonID); err != nil {
+		return nil, err
+	}
+	return s.getSeasonSettings(ctx, seasonID)
+}
+
+func (s *Store) getSeasonSettings(ctx context.Context, seasonID string) (*models.SeasonSettings, error) {
+	row := s.pool.QueryRow(ctx, `
+		select current_week, tie_scoring, standings_rule, auto_pick_rule
+		from season_settings
+		where season_id = $1
+	`, seasonID)
+
+	settings := models.SeasonSettings{
+		SeasonID:      seasonID,
+		CurrentWeek:   1,
+		TieScoring:    models.TieScoringZero,
+		StandingsRule: standings.RuleWeeksWon,
+		AutoPickRule:  autopick.RuleNone,
+	}
+	if err := row.Scan(&settings.CurrentWeek, &settings.TieScoring, &settings.StandingsRule, &settings.AutoPickRule); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &settings, nil
+		}
+		return nil, fmt.Errorf("store: get season settings: %w", err)
+	}
+	return &settings, nil
+}
+
+func (s *Store) UpdateSeasonSettings(ctx context.Context, seasonID string, update SeasonSettingsUpdate) (*models.SeasonSettings, error) {
+	if _, err := s.getSeason(ctx, seasonID); err != nil {
+		return nil, err
+	}
+
+	var tieScoring *string
+	if update.TieScoring != nil {
+		value := strings.ToLower(strings.TrimSpace(*update.TieScoring))
+		if _, ok := validTieScoring[value]; !ok {
+			return nil, fmt.Errorf("%w: tie scoring %q", ErrInvalidSettings, *update.TieScoring)
+		}
+		tieScoring = &value
+	}
+
+	var standingsRule *string
+	if update.StandingsRule != nil {
+		value := strings.ToLower(strings.TrimSpace(*update.StandingsRule))
+		if !standings.ValidRule(value) {
+			return nil, fmt.Errorf("%w: standings rule %q", ErrInvalidSettings, *update.StandingsRule)
+		}
+		standingsRule = &value
+	}
+
+	var autoPickRule *string
+	if update.AutoPickRule != nil {
+		value := strings.ToLower(strings.TrimSpace(*update.AutoPickRule))
+		if !autopick.ValidRule(value) {
+			return nil, fmt.Errorf("%w: auto-pick rule %q", ErrInvalidSettings, *update.AutoPickRule)
+		}
+		autoPickRule = &value
+	}
+
+	if _, err := s.pool.Exec(ctx, `
+		insert into season_settings (season_id, tie_scoring, standings_rule, auto_pick_rule)
+		values ($1, coalesce($2::text, 'zero'), coalesce($3::text, 'weeks-won'), coalesce($4::text, 'none'))
+		on conflict (season_id)
+		do update set
+			tie_scoring = coalesce($2::text, season_settings.tie_scoring),
+			standings_rule = coalesce($3::text, season_settings.standings_rule),
+			auto_pick_rule = coalesce($4::text, season_settings.auto_pick_rule),
+			updated_at = now()
+	`, seasonID, tieScoring, standingsRule, autoPickRule); err != nil {
+		return nil, fmt.Errorf("store: update season settings: %w", err)
+	}
+
+	return s.getSeasonSettings(ctx, seasonID)
+}
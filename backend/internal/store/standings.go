@@ -0,0 +1,159 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"pickem/backend/internal/models"
+	"pickem/backend/internal/standings"
+)
+
+var ErrInvalidStandingsRule = errors.New("store: invalid standings rule")
+
+// GetStandings ranks every member for the season. An empty rule uses the
+// season's configured rule; throughWeek <= 0 means the latest week with a
+// graded pick or declared winner.
+func (s *Store) GetStandings(ctx context.Context, seasonID, rule string, throughWeek int) (*models.Standings, error) {
+	if _, err := s.getSeason(ctx, seasonID); err != nil {
+		return nil, err
+	}
+
+	settings, err := s.getSeasonSettings(ctx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	rule = strings.ToLower(strings.TrimSpace(rule))
+	if rule == "" {
+		rule = settings.StandingsRule
+	}
+	if !standings.ValidRule(rule) {
+		return nil, fmt.Errorf("%w %q", ErrInvalidStandingsRule, rule)
+	}
+
+	members, err := s.loadMemberWeeks(ctx, seasonID, settings.TieScoring)
+	if err != nil {
+		return nil, err
+	}
+
+	weeks, err := s.listSeasonWeeks(ctx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+	weekNumbers := make([]int, 0, len(weeks))
+	for _, week := range weeks {
+		weekNumbers = append(weekNumbers, week.Number)
+	}
+
+	if throughWeek <= 0 {
+		throughWeek = latestDecidedWeek(members)
+	}
+
+	result := standings.Build(members, weekNumbers, rule, throughWeek)
+	result.SeasonID = seasonID
+	return &result, nil
+}
+
+// loadMemberWeeks returns every family member with their graded record and
+// weekly wins for each week of the season.
+func (s *Store) loadMemberWeeks(ctx context.Context, seasonID, tieScoring string) ([]standings.MemberWeeks, error) {
+	rows, err := s.pool.Query(ctx, `
+		select id, name
+		from family_members
+		order by name asc
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("store: list members: %w", err)
+	}
+	defer rows.Close()
+
+	members := []standings.MemberWeeks{}
+	memberIndex := map[string]int{}
+	for rows.Next() {
+		member := standings.MemberWeeks{
+			Records:  map[int]models.RecordSummary{},
+			WeeksWon: map[int]bool{},
+		}
+		if err := rows.Scan(&member.MemberID, &member.Name); err != nil {
+			return nil, fmt.Errorf("store: scan member: %w", err)
+		}
+		memberIndex[member.MemberID] = len(members)
+		members = append(members, member)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	recordRows, err := s.pool.Query(ctx, `
+		select p.member_id, w.number,
+			sum(case when g.status in ('final', 'forfeit') and g.winner = p.chosen_side then 1 else 0 end) as wins,
+			sum(case when g.status in ('final', 'forfeit') and g.winner is not null and g.winner not in (p.chosen_side, 'tie') then 1 else 0 end) as losses,
+			sum(case when g.status in ('final', 'forfeit') and g.winner = 'tie' then 1 else 0 end) as ties
+		from picks p
+			join games g on g.id = p.game_id
+			join season_weeks w on w.id = g.season_week_id
+		where w.season_id = $1
+		group by p.member_id, w.number
+	`, seasonID)
+	if err != nil {
+		return nil, fmt.Errorf("store: weekly records: %w", err)
+	}
+	defer recordRows.Close()
+
+	for recordRows.Next() {
+		var memberID string
+		var weekNumber, wins, losses, ties int
+		if err := recordRows.Scan(&memberID, &weekNumber, &wins, &losses, &ties); err != nil {
+			return nil, fmt.Errorf("store: weekly record scan: %w", err)
+		}
+		if idx, ok := memberIndex[memberID]; ok {
+			members[idx].Records[weekNumber] = newRecordSummary(wins, losses, ties, tieScoring)
+		}
+	}
+	if err := recordRows.Err(); err != nil {
+		return nil, err
+	}
+
+	winnerRows, err := s.pool.Query(ctx, `
+		select wr.winner_member_id, w.number
+		from week_results wr
+			join season_weeks w on w.id = wr.season_week_id
+		where w.season_id = $1
+			and wr.winner_member_id is not null
+	`, seasonID)
+	if err != nil {
+		return nil, fmt.Errorf("store: week winners: %w", err)
+	}
+	defer winnerRows.Close()
+
+	for winnerRows.Next() {
+		var memberID string
+		var weekNumber int
+		if err := winnerRows.Scan(&memberID, &weekNumber); err != nil {
+			return nil, fmt.Errorf("store: week winner scan: %w", err)
+		}
+		if idx, ok := memberIndex[memberID]; ok {
+			members[idx].WeeksWon[weekNumber] = true
+		}
+	}
+	return members, winnerRows.Err()
+}
+
+func latestDecidedWeek(members []standings.MemberWeeks) int {
+	latest := 0
+	for _, member := range members {
+		for week, record := range member.Records {
+			if record.Wins+record.Losses+record.Ties > 0 && week > latest {
+				latest = week
+			}
+		}
+		for week := range member.WeeksWon {
+			if week > latest {
+				latest = week
+			}
+		}
+	}
+	return latest
+}
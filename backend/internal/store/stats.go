@@ -0,0 +1,66 @@
+package store
+
+import (
+	"context"
+
+	"pickem/backend/internal/models"
+	"pickem/backend/internal/standings"
+	"pickem/backend/internal/stats"
+)
+
+// GetMemberStats breaks down a member's picks for the season: accuracy by
+// team, side and favorite, streaks, best and worst weeks, and how often they
+// sided with the rest of the family.
+func (s *Store) GetMemberStats(ctx context.Context, seasonID, memberID string) (*models.MemberStats, error) {
+	if _, err := s.getSeason(ctx, seasonID); err != nil {
+		return nil, err
+	}
+
+	settings, err := s.getSeasonSettings(ctx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := s.loadMemberWeeks(ctx, seasonID, settings.TieScoring)
+	if err != nil {
+		return nil, err
+	}
+	var member *standings.MemberWeeks
+	for i := range members {
+		if members[i].MemberID == memberID {
+			member = &members[i]
+			break
+		}
+	}
+	if member == nil {
+		return nil, ErrMemberNotFound
+	}
+
+	weeks, err := s.listSeasonGames(ctx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := stats.Member(memberID, weeks, member.WeeksWon, tiePointValue(settings.TieScoring))
+	result.SeasonID = seasonID
+	result.Name = member.Name
+	return &result, nil
+}
+
+// listSeasonGames loads every week's games and picks, in week order.
+func (s *Store) listSeasonGames(ctx context.Context, seasonID string) ([]stats.WeekGames, error) {
+	weeks, err := s.listSeasonWeeks(ctx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]stats.WeekGames, 0, len(weeks))
+	for _, week := range weeks {
+		games, err := s.listGamesWithPicks(ctx, week.ID)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, stats.WeekGames{ID: week.ID, Number: week.Number, Games: games})
+	}
+	return result, nil
+}
@@ -12,18 +12,24 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"pickem/backend/internal/models"
-	"pickem/backend/internal/nfl"
-	"pickem/backend/sportsdata"
+	"pickem/backend/internal/stats"
 )
 
 var (
 	ErrSeasonNotFound = errors.New("store: season not found")
 	ErrWeekNotFound   = errors.New("store: week not found")
 	ErrGameNotFound   = errors.New("store: game not found")
+	ErrMemberNotFound = errors.New("store: member not found")
+	ErrPickLocked     = errors.New("store: picks are locked for this game")
 	validSides        = map[string]struct{}{
 		"home": {},
 		"away": {},
 	}
+	validWinners = map[string]struct{}{
+		"home":               {},
+		"away":               {},
+		models.GameWinnerTie: {},
+	}
 )
 
 type Store struct {
@@ -111,8 +117,20 @@ func (s *Store) GetPageData(ctx context.Context, seasonID string, weekNumber int
 	if err != nil {
 		return nil, err
 	}
+	for i := range games {
+		games[i].Consensus = stats.GameConsensus(games[i])
+	}
+	if err := s.attachGameTags(ctx, week.ID, games); err != nil {
+		return nil, err
+	}
 	page.Games = games
 
+	settings, err := s.getSeasonSettings(ctx, season.ID)
+	if err != nil {
+		return nil, err
+	}
+	page.Summary = stats.Summarize(games, tiePointValue(settings.TieScoring))
+
 	weekResult, err := s.getWeekResult(ctx, week.ID)
 	if err != nil {
 		return nil, err
@@ -179,7 +197,7 @@ func (s *Store) listSeasonWeeks(ctx context.Context, seasonID string) ([]models.
 	}
 	defer rows.Close()
 
-	var weeks []models.Week
+	weeks := []models.Week{}
 	for rows.Next() {
 		var wk models.Week
 		if err := rows.Scan(&wk.ID, &wk.Number, &wk.Label, &wk.StartsAt, &wk.EndsAt); err != nil {
@@ -288,11 +306,16 @@ func (s *Store) listMembersWithStats(ctx context.Context, seasonID string, activ
 		return members, nil
 	}
 
-	if err := s.populateSeasonRecords(ctx, seasonID, memberIndex, members); err != nil {
+	settings, err := s.getSeasonSettings(ctx, seasonID)
+	if err != nil {
 		return nil, err
 	}
 
-	if err := s.populateLastWeekRecords(ctx, seasonID, activeWeek.Number-1, memberIndex, members); err != nil {
+	if err := s.populateSeasonRecords(ctx, seasonID, settings.TieScoring, memberIndex, members); err != nil {
+		return nil, err
+	}
+
+	if err := s.populateLastWeekRecords(ctx, seasonID, activeWeek.Number-1, settings.TieScoring, memberIndex, members); err != nil {
 		return nil, err
 	}
 
@@ -304,14 +327,19 @@ func (s *Store) listMembersWithStats(ctx context.Context, seasonID string, activ
 		return nil, err
 	}
 
+	if err := s.populateRatings(ctx, seasonID, activeWeek.Number, memberIndex, members); err != nil {
+		return nil, err
+	}
+
 	return members, nil
 }
 
-func (s *Store) populateSeasonRecords(ctx context.Context, seasonID string, memberIndex map[string]int, members []models.Member) error {
+func (s *Store) populateSeasonRecords(ctx context.Context, seasonID, tieScoring string, memberIndex map[string]int, members []models.Member) error {
 	rows, err := s.pool.Query(ctx, `
 		select p.member_id,
-			sum(case when g.status = 'final' and g.winner = p.chosen_side then 1 else 0 end) as wins,
-			sum(case when g.status = 'final' and g.winner is not null and g.winner <> p.chosen_side then 1 else 0 end) as losses
+			sum(case when g.status in ('final', 'forfeit') and g.winner = p.chosen_side then 1 else 0 end) as wins,
+			sum(case when g.status in ('final', 'forfeit') and g.winner is not null and g.winner not in (p.chosen_side, 'tie') then 1 else 0 end) as losses,
+			sum(case when g.status in ('final', 'forfeit') and g.winner = 'tie' then 1 else 0 end) as ties
 		from picks p
 			join games g on g.id = p.game_id
 			join season_weeks w on w.id = g.season_week_id
@@ -325,26 +353,27 @@ func (s *Store) populateSeasonRecords(ctx context.Context, seasonID string, memb
 
 	for rows.Next() {
 		var memberID string
-		var wins, losses int
-		if err := rows.Scan(&memberID, &wins, &losses); err != nil {
+		var wins, losses, ties int
+		if err := rows.Scan(&memberID, &wins, &losses, &ties); err != nil {
 			return fmt.Errorf("store: season record scan: %w", err)
 		}
 		if idx, ok := memberIndex[memberID]; ok {
-			members[idx].SeasonRecord = models.RecordSummary{Wins: wins, Losses: losses}
+			members[idx].SeasonRecord = newRecordSummary(wins, losses, ties, tieScoring)
 		}
 	}
 	return rows.Err()
 }
 
-func (s *Store) populateLastWeekRecords(ctx context.Context, seasonID string, weekNumber int, memberIndex map[string]int, members []models.Member) error {
+func (s *Store) populateLastWeekRecords(ctx context.Context, seasonID string, weekNumber int, tieScoring string, memberIndex map[string]int, members []models.Member) error {
 	if weekNumber <= 0 {
 		return nil
 	}
 
 	rows, err := s.pool.Query(ctx, `
 		select p.member_id,
-			sum(case when g.status = 'final' and g.winner = p.chosen_side then 1 else 0 end) as wins,
-			sum(case when g.status = 'final' and g.winner is not null and g.winner <> p.chosen_side then 1 else 0 end) as losses
+			sum(case when g.status in ('final', 'forfeit') and g.winner = p.chosen_side then 1 else 0 end) as wins,
+			sum(case when g.status in ('final', 'forfeit') and g.winner is not null and g.winner not in (p.chosen_side, 'tie') then 1 else 0 end) as losses,
+			sum(case when g.status in ('final', 'forfeit') and g.winner = 'tie' then 1 else 0 end) as ties
 		from picks p
 			join games g on g.id = p.game_id
 			join season_weeks w on w.id = g.season_week_id
@@ -359,17 +388,38 @@ func (s *Store) populateLastWeekRecords(ctx context.Context, seasonID string, we
 
 	for rows.Next() {
 		var memberID string
-		var wins, losses int
-		if err := rows.Scan(&memberID, &wins, &losses); err != nil {
+		var wins, losses, ties int
+		if err := rows.Scan(&memberID, &wins, &losses, &ties); err != nil {
 			return fmt.Errorf("store: last week record scan: %w", err)
 		}
 		if idx, ok := memberIndex[memberID]; ok {
-			members[idx].LastWeekRecord = models.RecordSummary{Wins: wins, Losses: losses}
+			members[idx].LastWeekRecord = newRecordSummary(wins, losses, ties, tieScoring)
 		}
 	}
 	return rows.Err()
 }
 
+// newRecordSummary totals a record, crediting pushes according to the
+// season's tie scoring mode.
+func newRecordSummary(wins, losses, ties int, tieScoring string) models.RecordSummary {
+	return models.RecordSummary{
+		Wins:   wins,
+		Losses: losses,
+		Ties:   ties,
+		Points: float64(wins) + float64(ties)*tiePointValue(tieScoring),
+	}
+}
+
+func tiePointValue(tieScoring string) float64 {
+	switch tieScoring {
+	case models.TieScoringHalf:
+		return 0.5
+	case models.TieScoringWin:
+		return 1
+	}
+	return 0
+}
+
 func (s *Store) populateWeeksWon(ctx context.Context, seasonID string, memberIndex map[string]int, members []models.Member) error {
 	rows, err := s.pool.Query(ctx, `
 		select wr.winner_member_id, count(*)
@@ -463,9 +513,20 @@ func (s *Store) listGamesWithPicks(ctx context.Context, seasonWeekID string) ([]
 			g.home_score,
 			g.away_score,
 			g.winner,
+			mw.number,
+			g.result_source,
+			g.result_reason,
+			g.result_updated_at,
+			g.point_spread,
+			g.over_under,
+			g.home_moneyline,
+			g.away_moneyline,
 			p.member_id,
-			p.chosen_side
+			p.chosen_side,
+			p.is_auto,
+			p.entered_by::text
 		from games g
+			left join season_weeks mw on mw.id = g.moved_from_week_id
 			left join picks p on p.game_id = g.id
 		where g.season_week_id = $1
 		order by g.kickoff nulls last, g.game_key asc
@@ -491,8 +552,18 @@ func (s *Store) listGamesWithPicks(ctx context.Context, seasonWeekID string) ([]
 			homeScore  *int
 			awayScore  *int
 			winner     *string
+			movedFrom  *int
+			source     string
+			reason     *string
+			resultAt   *time.Time
+			spread     *float64
+			overUnder  *float64
+			homeLine   *int
+			awayLine   *int
 			memberID   *string
 			chosenSide *string
+			isAuto     *bool
+			enteredBy  *string
 		)
 
 		if err := rows.Scan(
@@ -507,8 +578,18 @@ func (s *Store) listGamesWithPicks(ctx context.Context, seasonWeekID string) ([]
 			&homeScore,
 			&awayScore,
 			&winner,
+			&movedFrom,
+			&source,
+			&reason,
+			&resultAt,
+			&spread,
+			&overUnder,
+			&homeLine,
+			&awayLine,
 			&memberID,
 			&chosenSide,
+			&isAuto,
+			&enteredBy,
 		); err != nil {
 			return nil, fmt.Errorf("store: scan game row: %w", err)
 		}
@@ -516,15 +597,23 @@ func (s *Store) listGamesWithPicks(ctx context.Context, seasonWeekID string) ([]
 		idx, exists := gameIndex[gameID]
 		if !exists {
 			game := models.Game{
-				ID:        gameID,
-				GameKey:   gameKey,
-				Kickoff:   kickoff,
-				Status:    status,
-				Channel:   derefString(channel),
-				Location:  derefString(location),
-				HomeScore: homeScore,
-				AwayScore: awayScore,
-				Winner:    derefString(winner),
+				ID:              gameID,
+				GameKey:         gameKey,
+				Kickoff:         kickoff,
+				Status:          status,
+				Channel:         derefString(channel),
+				Location:        derefString(location),
+				HomeScore:       homeScore,
+				AwayScore:       awayScore,
+				Winner:          derefString(winner),
+				MovedFromWeek:   movedFrom,
+				ResultSource:    source,
+				ResultReason:    derefString(reason),
+				ResultUpdatedAt: resultAt,
+				PointSpread:     spread,
+				OverUnder:       overUnder,
+				HomeMoneyLine:   homeLine,
+				AwayMoneyLine:   awayLine,
 			}
 
 			if err := json.Unmarshal(homeTeamB, &game.HomeTeam); err != nil {
@@ -546,6 +635,8 @@ func (s *Store) listGamesWithPicks(ctx context.Context, seasonWeekID string) ([]
 				MemberID:   *memberID,
 				ChosenSide: *chosenSide,
 				Status:     pickStatus(games[idx], *chosenSide),
+				Auto:       isAuto != nil && *isAuto,
+				EnteredBy:  derefString(enteredBy),
 			}
 			games[idx].Picks = append(games[idx].Picks, pick)
 		}
@@ -558,13 +649,17 @@ func (s *Store) listGamesWithPicks(ctx context.Context, seasonWeekID string) ([]
 }
 
 func pickStatus(game models.Game, side string) string {
-	if strings.EqualFold(game.Status, "final") && game.Winner != "" {
-		if game.Winner == side {
-			return "correct"
-		}
-		return "incorrect"
+	return pickStateFromStatus(game.Status, &game.Winner, side)
+}
+
+// isGradedStatus reports whether picks on a game with the given status are
+// scored against its winner.
+func isGradedStatus(status string) bool {
+	switch strings.ToLower(status) {
+	case models.GameStatusFinal, models.GameStatusForfeit:
+		return true
 	}
-	return "pending"
+	return false
 }
 
 func derefString(value *string) string {
@@ -574,85 +669,179 @@ func derefString(value *string) string {
 	return *value
 }
 
-func (s *Store) UpsertPick(ctx context.Context, memberID, gameKey, chosenSide string) (*models.GamePick, error) {
-	if _, ok := validSides[chosenSide]; !ok {
-		return nil, fmt.Errorf("store: invalid side %q", chosenSide)
-	}
-
+// UpsertPick saves a member's pick. enteredBy names whoever submitted it
+// when that was not the member (the commissioner entering a pick on their
+// behalf); pass "" when members pick for themselves. Picks lock at kickoff,
+// or as soon as the game leaves scheduled, with ErrPickLocked.
+func (s *Store) UpsertPick(ctx context.Context, memberID, gameKey, chosenSide, enteredBy string) (*models.GamePick, error) {
 	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("store: upsert pick begin tx: %w", err)
 	}
 	defer tx.Rollback(ctx)
 
+	pick, err := upsertPick(ctx, tx, memberID, gameKey, chosenSide, enteredBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("store: upsert pick commit: %w", err)
+	}
+	return pick, nil
+}
+
+// upsertPick is UpsertPick within the caller's transaction.
+func upsertPick(ctx context.Context, tx pgx.Tx, memberID, gameKey, chosenSide, enteredBy string) (*models.GamePick, error) {
+	if _, ok := validSides[chosenSide]; !ok {
+		return nil, fmt.Errorf("store: invalid side %q", chosenSide)
+	}
+
 	var gameID string
 	var gameStatus string
 	var winner *string
-	err = tx.QueryRow(ctx, `
-		select id, status, winner
+	var kickoff *time.Time
+	err := tx.QueryRow(ctx, `
+		select id, status, winner, kickoff
 		from games
 		where game_key = $1
-	`, gameKey).Scan(&gameID, &gameStatus, &winner)
+		for update
+	`, gameKey).Scan(&gameID, &gameStatus, &winner, &kickoff)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, fmt.Errorf("store: unknown game %s", gameKey)
 		}
 		return nil, fmt.Errorf("store: query game: %w", err)
 	}
+	if !picksOpen(gameStatus, kickoff, time.Now()) {
+		return nil, fmt.Errorf("%w %s", ErrPickLocked, gameKey)
+	}
 
+	enteredBy = proxyMember(memberID, enteredBy)
 	_, err = tx.Exec(ctx, `
-		insert into picks (member_id, game_id, chosen_side)
-		values ($1, $2, $3)
+		insert into picks (member_id, game_id, chosen_side, entered_by)
+		values ($1, $2, $3, $4)
 		on conflict (member_id, game_id)
-		do update set chosen_side = excluded.chosen_side, updated_at = now()
-	`, memberID, gameID, chosenSide)
+		do update set
+			chosen_side = excluded.chosen_side,
+			entered_by = excluded.entered_by,
+			is_auto = false,
+			updated_at = now()
+	`, memberID, gameID, chosenSide, nullIfEmpty(enteredBy))
 	if err != nil {
 		return nil, fmt.Errorf("store: upsert pick: %w", err)
 	}
 
-	if err := tx.Commit(ctx); err != nil {
-		return nil, fmt.Errorf("store: upsert pick commit: %w", err)
+	if err := recordPickHistory(ctx, tx, memberID, gameID, models.PickActionSaved, chosenSide, enteredBy); err != nil {
+		return nil, err
 	}
 
 	pick := &models.GamePick{
 		MemberID:   memberID,
 		ChosenSide: chosenSide,
 		Status:     pickStateFromStatus(gameStatus, winner, chosenSide),
+		EnteredBy:  enteredBy,
 	}
 	return pick, nil
 }
 
-func (s *Store) DeletePick(ctx context.Context, memberID, gameKey string) error {
+// DeletePick removes a member's pick. enteredBy and locking follow
+// UpsertPick.
+func (s *Store) DeletePick(ctx context.Context, memberID, gameKey, enteredBy string) error {
 	if strings.TrimSpace(memberID) == "" || strings.TrimSpace(gameKey) == "" {
 		return fmt.Errorf("store: delete pick requires member and game key")
 	}
 
-	_, err := s.pool.Exec(ctx, `
-		delete from picks p
-		using games g
-		where p.game_id = g.id
-			and p.member_id = $1
-			and g.game_key = $2
-	`, memberID, gameKey)
+	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("store: delete pick begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var gameID string
+	var gameStatus string
+	var kickoff *time.Time
+	err = tx.QueryRow(ctx, `
+		select id, status, kickoff
+		from games
+		where game_key = $1
+		for update
+	`, gameKey).Scan(&gameID, &gameStatus, &kickoff)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		return fmt.Errorf("store: query game: %w", err)
+	}
+	if !picksOpen(gameStatus, kickoff, time.Now()) {
+		return fmt.Errorf("%w %s", ErrPickLocked, gameKey)
+	}
+
+	tag, err := tx.Exec(ctx, `
+		delete from picks
+		where member_id = $1 and game_id = $2
+	`, memberID, gameID)
 	if err != nil {
 		return fmt.Errorf("store: delete pick: %w", err)
 	}
+	if tag.RowsAffected() == 0 {
+		return nil
+	}
 
+	if err := recordPickHistory(ctx, tx, memberID, gameID, models.PickActionDeleted, "", proxyMember(memberID, enteredBy)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("store: delete pick commit: %w", err)
+	}
 	return nil
 }
 
+// picksOpen reports whether members may still change their pick on a game:
+// it has not kicked off and nothing has moved it past scheduled.
+func picksOpen(status string, kickoff *time.Time, now time.Time) bool {
+	return status == models.GameStatusScheduled && (kickoff == nil || kickoff.After(now))
+}
+
+// pickStateFromStatus grades a pick. Picks on canceled games are void: they
+// count as neither a win nor a loss. Postponed and suspended games stay
+// pending until the game is played, even if it moves to another week.
 func pickStateFromStatus(gameStatus string, winner *string, chosenSide string) string {
-	if strings.EqualFold(gameStatus, "final") && winner != nil && *winner != "" {
+	if strings.EqualFold(gameStatus, models.GameStatusCanceled) {
+		return models.PickStatusVoid
+	}
+	if isGradedStatus(gameStatus) && winner != nil && *winner != "" {
+		if *winner == models.GameWinnerTie {
+			return models.PickStatusPush
+		}
 		if *winner == chosenSide {
-			return "correct"
+			return models.PickStatusCorrect
 		}
-		return "incorrect"
+		return models.PickStatusIncorrect
 	}
-	return "pending"
+	return models.PickStatusPending
 }
 
 func (s *Store) UpsertTieBreaker(ctx context.Context, memberID, seasonWeekID string, points int) error {
-	_, err := s.pool.Exec(ctx, `
+	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("store: upsert tie breaker begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := upsertTieBreaker(ctx, tx, memberID, seasonWeekID, points); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("store: upsert tie breaker commit: %w", err)
+	}
+	return nil
+}
+
+func upsertTieBreaker(ctx context.Context, tx pgx.Tx, memberID, seasonWeekID string, points int) error {
+	_, err := tx.Exec(ctx, `
 		insert into tie_breakers (member_id, season_week_id, points)
 		values ($1, $2, $3)
 		on conflict (member_id, season_week_id)
@@ -692,29 +881,54 @@ func (s *Store) DeclareWeekWinner(ctx context.Context, seasonWeekID, winnerMembe
 	return &result, nil
 }
 
-func (s *Store) UpdateGameWinner(ctx context.Context, seasonWeekID, gameKey, winner string) (*models.Game, error) {
+// UpdateGameWinner records a commissioner result. Setting a winner marks the
+// result manual so provider syncs keep it; clearing the winner unlocks the game
+// and hands it back to the provider.
+func (s *Store) UpdateGameWinner(ctx context.Context, seasonWeekID, gameKey, winner, reason string) (*models.Game, error) {
 	gameKey = strings.TrimSpace(gameKey)
 	if gameKey == "" {
 		return nil, fmt.Errorf("store: update game winner requires game key")
 	}
 
 	winner = strings.ToLower(strings.TrimSpace(winner))
-	if winner != "" {
-		if _, ok := validSides[winner]; !ok {
-			return nil, fmt.Errorf("store: invalid winner %q", winner)
-		}
+	if winner == "" {
+		// The override set status 'final'; undo that too.
+		return s.UnlockGame(ctx, seasonWeekID, gameKey)
+	}
+	if _, ok := validWinners[winner]; !ok {
+		return nil, fmt.Errorf("store: invalid winner %q", winner)
 	}
 
 	row := s.pool.QueryRow(ctx, `
 		update games
 		set
-			winner = nullif($3, ''),
-			status = case when $3 <> '' then 'final' else status end,
+			winner = $3,
+			status = 'final',
+			result_source = 'manual',
+			result_reason = nullif($4, ''),
+			result_updated_at = now(),
 			updated_at = now()
 		where season_week_id = $1 and game_key = $2
-		returning id, game_key, kickoff, status, channel, location, home_team, away_team, home_score, away_score, winner
-	`, seasonWeekID, gameKey, winner)
+		returning `+returnedGameColumns, seasonWeekID, gameKey, winner, strings.TrimSpace(reason))
+
+	game, err := scanReturnedGame(row, gameKey)
+	if err != nil {
+		if errors.Is(err, ErrGameNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("store: update game winner: %w", err)
+	}
+	return game, nil
+}
 
+// returnedGameColumns is the returning clause shared by single-game updates;
+// scanReturnedGame reads it back.
+const returnedGameColumns = `id, game_key, kickoff, status, channel, location, home_team, away_team, home_score, away_score, winner,
+			(select number from season_weeks where id = games.moved_from_week_id),
+			result_source, result_reason, result_updated_at,
+			point_spread, over_under, home_moneyline, away_moneyline`
+
+func scanReturnedGame(row pgx.Row, gameKey string) (*models.Game, error) {
 	var (
 		game       models.Game
 		kickoff    *time.Time
@@ -725,6 +939,8 @@ func (s *Store) UpdateGameWinner(ctx context.Context, seasonWeekID, gameKey, win
 		homeScore  *int
 		awayScore  *int
 		winnerText *string
+		movedFrom  *int
+		reason     *string
 	)
 
 	if err := row.Scan(
@@ -739,11 +955,19 @@ func (s *Store) UpdateGameWinner(ctx context.Context, seasonWeekID, gameKey, win
 		&homeScore,
 		&awayScore,
 		&winnerText,
+		&movedFrom,
+		&game.ResultSource,
+		&reason,
+		&game.ResultUpdatedAt,
+		&game.PointSpread,
+		&game.OverUnder,
+		&game.HomeMoneyLine,
+		&game.AwayMoneyLine,
 	); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrGameNotFound
 		}
-		return nil, fmt.Errorf("store: update game winner: %w", err)
+		return nil, err
 	}
 
 	game.Kickoff = kickoff
@@ -758,6 +982,8 @@ func (s *Store) UpdateGameWinner(ctx context.Context, seasonWeekID, gameKey, win
 	game.HomeScore = homeScore
 	game.AwayScore = awayScore
 	game.Winner = derefString(winnerText)
+	game.MovedFromWeek = movedFrom
+	game.ResultReason = derefString(reason)
 	game.Picks = []models.GamePick{}
 
 	return &game, nil
@@ -770,118 +996,6 @@ func nullIfEmpty(value string) interface{} {
 	return value
 }
 
-func (s *Store) SyncWeekFromSnapshots(ctx context.Context, season models.Season, week models.Week, snapshots []sportsdata.GameSnapshot) error {
-	if len(snapshots) == 0 {
-		return nil
-	}
-
-	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{})
-	if err != nil {
-		return fmt.Errorf("store: sync week begin tx: %w", err)
-	}
-	defer tx.Rollback(ctx)
-
-	for _, snap := range snapshots {
-		homeTeam := nfl.Lookup(snap.HomeTeam)
-		awayTeam := nfl.Lookup(snap.AwayTeam)
-
-		homeTeamJSON, err := json.Marshal(homeTeam)
-		if err != nil {
-			return fmt.Errorf("store: sync week marshal home team: %w", err)
-		}
-		awayTeamJSON, err := json.Marshal(awayTeam)
-		if err != nil {
-			return fmt.Errorf("store: sync week marshal away team: %w", err)
-		}
-
-		status := normalizeGameStatus(snap.Status, snap.IsClosed, snap.IsOver)
-		winner := ""
-		if status == "final" && snap.HomeScore != nil && snap.AwayScore != nil {
-			if *snap.HomeScore > *snap.AwayScore {
-				winner = "home"
-			} else if *snap.AwayScore > *snap.HomeScore {
-				winner = "away"
-			}
-		}
-
-		rawPayload, err := json.Marshal(snap)
-		if err != nil {
-			return fmt.Errorf("store: sync week marshal payload: %w", err)
-		}
-
-		_, err = tx.Exec(ctx, `
-			insert into games (
-				season_week_id,
-				game_key,
-				kickoff,
-				status,
-				channel,
-				location,
-				home_team,
-				away_team,
-				home_score,
-				away_score,
-				winner,
-				sportsdata_payload
-			)
-			values ($1, $2, $3, $4, nullif($5, ''), nullif($6, ''), $7, $8, $9, $10, nullif($11, ''), $12)
-			on conflict (game_key)
-			do update set
-				season_week_id = excluded.season_week_id,
-				kickoff = excluded.kickoff,
-				status = case when games.status = 'final' then games.status else excluded.status end,
-				channel = excluded.channel,
-				location = excluded.location,
-				home_team = excluded.home_team,
-				away_team = excluded.away_team,
-				home_score = excluded.home_score,
-				away_score = excluded.away_score,
-				winner = coalesce(excluded.winner, games.winner),
-				sportsdata_payload = excluded.sportsdata_payload,
-				updated_at = now()
-		`,
-			week.ID,
-			snap.GameKey,
-			parseOptionalTime(snap.Kickoff),
-			status,
-			snap.Channel,
-			snap.Location,
-			homeTeamJSON,
-			awayTeamJSON,
-			snap.HomeScore,
-			snap.AwayScore,
-			winner,
-			rawPayload,
-		)
-		if err != nil {
-			return fmt.Errorf("store: sync week upsert game %s: %w", snap.GameKey, err)
-		}
-	}
-
-	if err := tx.Commit(ctx); err != nil {
-		return fmt.Errorf("store: sync week commit: %w", err)
-	}
-
-	return nil
-}
-
-func normalizeGameStatus(status string, isClosed bool, isOver bool) string {
-	normalized := strings.ToLower(strings.TrimSpace(status))
-	if isOver || isClosed {
-		return "final"
-	}
-	switch normalized {
-	case "inprogress", "in-progress", "in progress", "playing":
-		return "in-progress"
-	case "final", "complete", "completed":
-		return "final"
-	}
-	if strings.HasPrefix(normalized, "f/") || strings.HasPrefix(normalized, "f ") || strings.HasPrefix(normalized, "f") {
-		return "final"
-	}
-	return "scheduled"
-}
-
 func parseOptionalTime(value *string) *time.Time {
 	if value == nil || strings.TrimSpace(*value) == "" {
 		return nil
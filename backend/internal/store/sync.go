@@ -0,0 +1,392 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"pickem/backend/internal/models"
+	"pickem/backend/internal/nfl"
+	"pickem/backend/sportsdata"
+)
+
+// SyncOptions tunes how provider snapshots are applied.
+type SyncOptions struct {
+	// OverrideManual lets provider results replace a commissioner override.
+	OverrideManual bool
+	// DryRun computes the report without committing any changes.
+	DryRun bool
+}
+
+// storedGame is the state of a game already in the database.
+type storedGame struct {
+	SeasonWeekID string
+	WeekNumber   int
+	Kickoff      *time.Time
+	Status       string
+	Winner       string
+	HomeScore    *int
+	AwayScore    *int
+	ResultSource string
+}
+
+func (s *Store) SyncWeekFromSnapshots(ctx context.Context, season models.Season, week models.Week, snapshots []sportsdata.GameSnapshot, opts SyncOptions) (*models.SyncReport, error) {
+	report := &models.SyncReport{
+		DryRun:    opts.DryRun,
+		Games:     []models.SyncGameDiff{},
+		Conflicts: []models.SyncConflict{},
+	}
+	if len(snapshots) == 0 {
+		return report, nil
+	}
+
+	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("store: sync week begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	current, err := lockStoredGames(ctx, tx, snapshots)
+	if err != nil {
+		return nil, err
+	}
+
+	pickSides, err := countPickSides(ctx, tx, snapshots)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, snap := range snapshots {
+		homeTeam := nfl.Lookup(snap.HomeTeam)
+		awayTeam := nfl.Lookup(snap.AwayTeam)
+
+		homeTeamJSON, err := json.Marshal(homeTeam)
+		if err != nil {
+			return nil, fmt.Errorf("store: sync week marshal home team: %w", err)
+		}
+		awayTeamJSON, err := json.Marshal(awayTeam)
+		if err != nil {
+			return nil, fmt.Errorf("store: sync week marshal away team: %w", err)
+		}
+
+		providerStatus, known := normalizeGameStatus(snap.Status, snap.IsClosed, snap.IsOver)
+		providerWinner := ""
+		if isGradedStatus(providerStatus) {
+			providerWinner = winnerFromScores(snap.HomeScore, snap.AwayScore)
+		}
+
+		kickoff := parseOptionalTime(snap.Kickoff)
+		status := providerStatus
+		winner := providerWinner
+		homeScore, awayScore := snap.HomeScore, snap.AwayScore
+		source := models.ResultSourceProvider
+		existing, exists := current[snap.GameKey]
+		if exists {
+			status = nextGameStatus(existing.Status, providerStatus, known)
+			if !isGradedStatus(status) {
+				winner = ""
+			}
+			if winner == "" {
+				winner = existing.Winner
+			}
+
+			if existing.ResultSource == models.ResultSourceManual {
+				if providerWinner != "" && providerWinner != existing.Winner {
+					report.Conflicts = append(report.Conflicts, models.SyncConflict{
+						GameKey:        snap.GameKey,
+						ManualWinner:   existing.Winner,
+						ProviderWinner: providerWinner,
+						ProviderStatus: providerStatus,
+						HomeScore:      snap.HomeScore,
+						AwayScore:      snap.AwayScore,
+						Overridden:     opts.OverrideManual,
+					})
+				}
+				if !opts.OverrideManual {
+					status = existing.Status
+					winner = existing.Winner
+					homeScore, awayScore = existing.HomeScore, existing.AwayScore
+					source = models.ResultSourceManual
+				}
+			}
+		}
+
+		rawPayload, err := json.Marshal(snap)
+		if err != nil {
+			return nil, fmt.Errorf("store: sync week marshal payload: %w", err)
+		}
+
+		_, err = tx.Exec(ctx, `
+			insert into games (
+				season_week_id,
+				game_key,
+				kickoff,
+				status,
+				channel,
+				location,
+				home_team,
+				away_team,
+				home_score,
+				away_score,
+				winner,
+				sportsdata_payload,
+				result_source,
+				result_updated_at,
+				point_spread,
+				over_under,
+				home_moneyline,
+				away_moneyline
+			)
+			values ($1, $2, $3, $4, nullif($5, ''), nullif($6, ''), $7, $8, $9, $10, nullif($11, ''), $12, $13,
+				case when $11 = '' then null else now() end, $14, $15, $16, $17)
+			on conflict (game_key)
+			do update set
+				season_week_id = excluded.season_week_id,
+				moved_from_week_id = case
+					when games.season_week_id <> excluded.season_week_id then games.season_week_id
+					else games.moved_from_week_id
+				end,
+				moved_at = case
+					when games.season_week_id <> excluded.season_week_id then now()
+					else games.moved_at
+				end,
+				kickoff = excluded.kickoff,
+				status = excluded.status,
+				channel = excluded.channel,
+				location = excluded.location,
+				home_team = excluded.home_team,
+				away_team = excluded.away_team,
+				home_score = excluded.home_score,
+				away_score = excluded.away_score,
+				winner = excluded.winner,
+				sportsdata_payload = excluded.sportsdata_payload,
+				point_spread = coalesce(excluded.point_spread, games.point_spread),
+				over_under = coalesce(excluded.over_under, games.over_under),
+				home_moneyline = coalesce(excluded.home_moneyline, games.home_moneyline),
+				away_moneyline = coalesce(excluded.away_moneyline, games.away_moneyline),
+				result_source = excluded.result_source,
+				result_reason = case
+					when excluded.result_source = games.result_source then games.result_reason
+					else null
+				end,
+				result_updated_at = case
+					when games.winner is distinct from excluded.winner
+						or games.result_source <> excluded.result_source then now()
+					else games.result_updated_at
+				end,
+				updated_at = now()
+		`,
+			week.ID,
+			snap.GameKey,
+			kickoff,
+			status,
+			snap.Channel,
+			snap.Location,
+			homeTeamJSON,
+			awayTeamJSON,
+			homeScore,
+			awayScore,
+			winner,
+			rawPayload,
+			source,
+			snap.PointSpread,
+			snap.OverUnder,
+			snap.HomeMoneyLine,
+			snap.AwayMoneyLine,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("store: sync week upsert game %s: %w", snap.GameKey, err)
+		}
+
+		after := models.SyncGameState{
+			WeekNumber: week.Number,
+			Kickoff:    kickoff,
+			Status:     status,
+			HomeScore:  homeScore,
+			AwayScore:  awayScore,
+			Winner:     winner,
+		}
+		diff := models.SyncGameDiff{
+			GameKey:  snap.GameKey,
+			HomeTeam: homeTeam.Code,
+			AwayTeam: awayTeam.Code,
+			After:    after,
+		}
+		if exists {
+			diff.Before = &models.SyncGameState{
+				WeekNumber: existing.WeekNumber,
+				Kickoff:    existing.Kickoff,
+				Status:     existing.Status,
+				HomeScore:  existing.HomeScore,
+				AwayScore:  existing.AwayScore,
+				Winner:     existing.Winner,
+			}
+			diff.Changes = gameChanges(existing, week.ID, after)
+			diff.RegradedPicks = regradedPicks(pickSides[snap.GameKey], existing, after)
+			if diff.RegradedPicks > 0 {
+				diff.Changes = append(diff.Changes, models.SyncChangeRegrade)
+			}
+		} else {
+			diff.Changes = []string{models.SyncChangeNew}
+		}
+
+		if len(diff.Changes) == 0 {
+			report.Unchanged++
+			continue
+		}
+		report.Games = append(report.Games, diff)
+	}
+
+	if opts.DryRun {
+		// The deferred rollback discards everything written above.
+		return report, nil
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("store: sync week commit: %w", err)
+	}
+
+	return report, nil
+}
+
+func gameChanges(before storedGame, seasonWeekID string, after models.SyncGameState) []string {
+	changes := []string{}
+	if before.SeasonWeekID != seasonWeekID {
+		changes = append(changes, models.SyncChangeWeek)
+	}
+	if !sameTime(before.Kickoff, after.Kickoff) {
+		changes = append(changes, models.SyncChangeKickoff)
+	}
+	if !sameInt(before.HomeScore, after.HomeScore) || !sameInt(before.AwayScore, after.AwayScore) {
+		changes = append(changes, models.SyncChangeScore)
+	}
+	if before.Status != after.Status {
+		changes = append(changes, models.SyncChangeStatus)
+	}
+	if before.Winner != after.Winner {
+		changes = append(changes, models.SyncChangeWinner)
+	}
+	return changes
+}
+
+// regradedPicks counts the picks whose grade changes between the stored and
+// synced state of a game.
+func regradedPicks(sides map[string]int, before storedGame, after models.SyncGameState) int {
+	regraded := 0
+	for side, count := range sides {
+		was := pickStateFromStatus(before.Status, &before.Winner, side)
+		now := pickStateFromStatus(after.Status, &after.Winner, side)
+		if was != now {
+			regraded += count
+		}
+	}
+	return regraded
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.Equal(*b)
+}
+
+func sameInt(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func winnerFromScores(homeScore, awayScore *int) string {
+	if homeScore == nil || awayScore == nil {
+		return ""
+	}
+	switch {
+	case *homeScore > *awayScore:
+		return "home"
+	case *awayScore > *homeScore:
+		return "away"
+	}
+	return models.GameWinnerTie
+}
+
+// lockStoredGames reads the stored result of every game in the snapshot set,
+// locking the rows for the rest of the sync transaction.
+func lockStoredGames(ctx context.Context, tx pgx.Tx, snapshots []sportsdata.GameSnapshot) (map[string]storedGame, error) {
+	rows, err := tx.Query(ctx, `
+		select g.game_key, g.season_week_id, w.number, g.kickoff, g.status, g.winner, g.home_score, g.away_score, g.result_source
+		from games g
+			join season_weeks w on w.id = g.season_week_id
+		where g.game_key = any($1)
+		for update of g
+	`, snapshotKeys(snapshots))
+	if err != nil {
+		return nil, fmt.Errorf("store: sync week load games: %w", err)
+	}
+	defer rows.Close()
+
+	games := map[string]storedGame{}
+	for rows.Next() {
+		var (
+			key    string
+			game   storedGame
+			winner *string
+		)
+		if err := rows.Scan(
+			&key,
+			&game.SeasonWeekID,
+			&game.WeekNumber,
+			&game.Kickoff,
+			&game.Status,
+			&winner,
+			&game.HomeScore,
+			&game.AwayScore,
+			&game.ResultSource,
+		); err != nil {
+			return nil, fmt.Errorf("store: sync week scan game: %w", err)
+		}
+		game.Winner = derefString(winner)
+		games[key] = game
+	}
+	return games, rows.Err()
+}
+
+// countPickSides tallies the picks on each synced game by chosen side.
+func countPickSides(ctx context.Context, tx pgx.Tx, snapshots []sportsdata.GameSnapshot) (map[string]map[string]int, error) {
+	rows, err := tx.Query(ctx, `
+		select g.game_key, p.chosen_side, count(*)
+		from picks p
+			join games g on g.id = p.game_id
+		where g.game_key = any($1)
+		group by g.game_key, p.chosen_side
+	`, snapshotKeys(snapshots))
+	if err != nil {
+		return nil, fmt.Errorf("store: sync week count picks: %w", err)
+	}
+	defer rows.Close()
+
+	sides := map[string]map[string]int{}
+	for rows.Next() {
+		var key, side string
+		var count int
+		if err := rows.Scan(&key, &side, &count); err != nil {
+			return nil, fmt.Errorf("store: sync week scan pick count: %w", err)
+		}
+		if sides[key] == nil {
+			sides[key] = map[string]int{}
+		}
+		sides[key][side] = count
+	}
+	return sides, rows.Err()
+}
+
+func snapshotKeys(snapshots []sportsdata.GameSnapshot) []string {
+	keys := make([]string, 0, len(snapshots))
+	for _, snap := range snapshots {
+		keys = append(keys, snap.GameKey)
+	}
+	return keys
+}
@@ -0,0 +1,206 @@
+package store
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	"pickem/backend/internal/models"
+)
+
+var (
+	ErrWebhookNotFound = errors.New("store: webhook not found")
+	ErrInvalidWebhook  = errors.New("store: invalid webhook")
+	ErrNotCommissioner = errors.New("store: member is not the commissioner")
+
+	validWebhookEvents = map[string]struct{}{
+		models.WebhookEventPickSaved:   {},
+		models.WebhookEventPickDeleted: {},
+		models.WebhookEventGameResult:  {},
+		models.WebhookEventWeekSynced:  {},
+		models.WebhookEventWeekWinner:  {},
+	}
+)
+
+// RequireCommissioner returns ErrNotCommissioner unless memberID is a
+// commissioner.
+func (s *Store) RequireCommissioner(ctx context.Context, memberID string) error {
+	var isCommissioner bool
+	err := s.pool.QueryRow(ctx, `
+		select is_commissioner
+		from family_members
+		where id::text = $1
+	`, memberID).Scan(&isCommissioner)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrMemberNotFound
+		}
+		return fmt.Errorf("store: lookup commissioner: %w", err)
+	}
+	if !isCommissioner {
+		return ErrNotCommissioner
+	}
+	return nil
+}
+
+// CreateWebhook registers a URL for the given events. An empty secret gets
+// a generated one; the secret is only returned here.
+func (s *Store) CreateWebhook(ctx context.Context, rawURL, secret string, events []string, createdByMemberID string) (*models.Webhook, error) {
+	parsed, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, fmt.Errorf("%w: url must be an absolute http(s) URL", ErrInvalidWebhook)
+	}
+	if len(events) == 0 {
+		return nil, fmt.Errorf("%w: at least one event is required", ErrInvalidWebhook)
+	}
+	seen := map[string]bool{}
+	normalized := make([]string, 0, len(events))
+	for _, event := range events {
+		event = strings.ToLower(strings.TrimSpace(event))
+		if _, ok := validWebhookEvents[event]; !ok {
+			return nil, fmt.Errorf("%w: unknown event %q", ErrInvalidWebhook, event)
+		}
+		if !seen[event] {
+			seen[event] = true
+			normalized = append(normalized, event)
+		}
+	}
+
+	if secret = strings.TrimSpace(secret); secret == "" {
+		buf := make([]byte, 24)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, fmt.Errorf("store: generate webhook secret: %w", err)
+		}
+		secret = hex.EncodeToString(buf)
+	}
+
+	var webhook models.Webhook
+	var createdBy *string
+	err = s.pool.QueryRow(ctx, `
+		insert into webhooks (url, secret, events, created_by_member_id)
+		values ($1, $2, $3, $4)
+		returning id, url, events, active, created_by_member_id, created_at
+	`, parsed.String(), secret, normalized, nullIfEmpty(createdByMemberID)).Scan(
+		&webhook.ID, &webhook.URL, &webhook.Events, &webhook.Active, &createdBy, &webhook.CreatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("store: create webhook: %w", err)
+	}
+	webhook.CreatedByMemberID = derefString(createdBy)
+	webhook.Secret = secret
+	return &webhook, nil
+}
+
+// ListWebhooks returns every registered webhook without its secret.
+func (s *Store) ListWebhooks(ctx context.Context) ([]models.Webhook, error) {
+	rows, err := s.pool.Query(ctx, `
+		select id, url, events, active, created_by_member_id, created_at
+		from webhooks
+		order by created_at asc
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("store: list webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	webhooks := []models.Webhook{}
+	for rows.Next() {
+		var webhook models.Webhook
+		var createdBy *string
+		if err := rows.Scan(&webhook.ID, &webhook.URL, &webhook.Events, &webhook.Active, &createdBy, &webhook.CreatedAt); err != nil {
+			return nil, fmt.Errorf("store: scan webhook: %w", err)
+		}
+		webhook.CreatedByMemberID = derefString(createdBy)
+		webhooks = append(webhooks, webhook)
+	}
+	return webhooks, rows.Err()
+}
+
+// ListWebhooksForEvent returns the active webhooks subscribed to the event,
+// secrets included, for delivery.
+func (s *Store) ListWebhooksForEvent(ctx context.Context, event string) ([]models.Webhook, error) {
+	rows, err := s.pool.Query(ctx, `
+		select id, url, secret
+		from webhooks
+		where active and $1 = any(events)
+	`, event)
+	if err != nil {
+		return nil, fmt.Errorf("store: webhooks for event: %w", err)
+	}
+	defer rows.Close()
+
+	webhooks := []models.Webhook{}
+	for rows.Next() {
+		webhook := models.Webhook{Events: []string{event}, Active: true}
+		if err := rows.Scan(&webhook.ID, &webhook.URL, &webhook.Secret); err != nil {
+			return nil, fmt.Errorf("store: scan webhook: %w", err)
+		}
+		webhooks = append(webhooks, webhook)
+	}
+	return webhooks, rows.Err()
+}
+
+func (s *Store) DeleteWebhook(ctx context.Context, webhookID string) error {
+	tag, err := s.pool.Exec(ctx, `delete from webhooks where id::text = $1`, webhookID)
+	if err != nil {
+		return fmt.Errorf("store: delete webhook: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrWebhookNotFound
+	}
+	return nil
+}
+
+// RecordWebhookDelivery logs one delivery attempt along with the payload
+// that was sent.
+func (s *Store) RecordWebhookDelivery(ctx context.Context, delivery models.WebhookDelivery, payload []byte) error {
+	if _, err := s.pool.Exec(ctx, `
+		insert into webhook_deliveries (webhook_id, delivery_id, event, payload, attempt, status_code, error, delivered)
+		values ($1, $2, $3, $4, $5, $6, $7, $8)
+	`, delivery.WebhookID, delivery.DeliveryID, delivery.Event, payload, delivery.Attempt, delivery.StatusCode, nullIfEmpty(delivery.Error), delivery.Delivered); err != nil {
+		return fmt.Errorf("store: record webhook delivery: %w", err)
+	}
+	return nil
+}
+
+// ListWebhookDeliveries returns the most recent delivery attempts for a
+// webhook, newest first.
+func (s *Store) ListWebhookDeliveries(ctx context.Context, webhookID string, limit int) ([]models.WebhookDelivery, error) {
+	var exists bool
+	if err := s.pool.QueryRow(ctx, `select exists (select 1 from webhooks where id::text = $1)`, webhookID).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("store: lookup webhook: %w", err)
+	}
+	if !exists {
+		return nil, ErrWebhookNotFound
+	}
+
+	rows, err := s.pool.Query(ctx, `
+		select id, webhook_id, delivery_id, event, attempt, status_code, error, delivered, created_at
+		from webhook_deliveries
+		where webhook_id::text = $1
+		order by created_at desc
+		limit $2
+	`, webhookID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("store: list webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := []models.WebhookDelivery{}
+	for rows.Next() {
+		var delivery models.WebhookDelivery
+		var deliveryErr *string
+		if err := rows.Scan(&delivery.ID, &delivery.WebhookID, &delivery.DeliveryID, &delivery.Event, &delivery.Attempt, &delivery.StatusCode, &deliveryErr, &delivery.Delivered, &delivery.CreatedAt); err != nil {
+			return nil, fmt.Errorf("store: scan webhook delivery: %w", err)
+		}
+		delivery.Error = derefString(deliveryErr)
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, rows.Err()
+}
@@ -0,0 +1,177 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"pickem/backend/internal/models"
+)
+
+const (
+	maxAttempts  = 4
+	firstBackoff = 2 * time.Second
+	// deliveryTimeout bounds a whole delivery, retries included.
+	deliveryTimeout = 2 * time.Minute
+
+	// Request headers. The signature is the hex HMAC-SHA256 of the raw body
+	// keyed with the webhook's secret, prefixed with "sha256=".
+	HeaderEvent     = "X-Pickem-Event"
+	HeaderDelivery  = "X-Pickem-Delivery"
+	HeaderSignature = "X-Pickem-Signature"
+)
+
+// Registry is where subscriptions live and delivery attempts are logged.
+type Registry interface {
+	ListWebhooksForEvent(ctx context.Context, event string) ([]models.Webhook, error)
+	RecordWebhookDelivery(ctx context.Context, delivery models.WebhookDelivery, payload []byte) error
+}
+
+// Payload is the JSON body of every delivery.
+type Payload struct {
+	ID         string    `json:"id"`
+	Event      string    `json:"event"`
+	OccurredAt time.Time `json:"occurredAt"`
+	Data       any       `json:"data"`
+}
+
+// Dispatcher delivers events to subscribed webhooks in the background.
+type Dispatcher struct {
+	registry Registry
+	client   *http.Client
+}
+
+func NewDispatcher(registry Registry) *Dispatcher {
+	return &Dispatcher{
+		registry: registry,
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Fire queues the event for every subscribed webhook and returns straight
+// away; delivery never holds up the request that caused it.
+func (d *Dispatcher) Fire(event string, data any) {
+	if d == nil || d.registry == nil {
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), deliveryTimeout)
+		defer cancel()
+
+		targets, err := d.registry.ListWebhooksForEvent(ctx, event)
+		if err != nil {
+			log.Printf("webhooks: list subscribers for %s: %v", event, err)
+			return
+		}
+		if len(targets) == 0 {
+			return
+		}
+
+		payload := Payload{ID: newDeliveryID(), Event: event, OccurredAt: time.Now().UTC(), Data: data}
+		body, err := json.Marshal(payload)
+		if err != nil {
+			log.Printf("webhooks: encode %s: %v", event, err)
+			return
+		}
+
+		var wg sync.WaitGroup
+		for _, target := range targets {
+			wg.Add(1)
+			go func(target models.Webhook) {
+				defer wg.Done()
+				d.deliver(ctx, target, payload, body)
+			}(target)
+		}
+		wg.Wait()
+	}()
+}
+
+// deliver posts the payload, retrying with exponential backoff on network
+// errors and non-2xx responses. Every attempt is logged.
+func (d *Dispatcher) deliver(ctx context.Context, target models.Webhook, payload Payload, body []byte) {
+	backoff := firstBackoff
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		record := models.WebhookDelivery{
+			WebhookID:  target.ID,
+			DeliveryID: payload.ID,
+			Event:      payload.Event,
+			Attempt:    attempt,
+		}
+
+		status, err := d.post(ctx, target, payload, body)
+		if status != 0 {
+			record.StatusCode = &status
+		}
+		if err != nil {
+			record.Error = err.Error()
+		} else {
+			record.Delivered = true
+		}
+		if logErr := d.registry.RecordWebhookDelivery(ctx, record, body); logErr != nil {
+			log.Printf("webhooks: %v", logErr)
+		}
+		if record.Delivered {
+			return
+		}
+
+		if attempt == maxAttempts {
+			log.Printf("webhooks: giving up on %s for webhook %s: %v", payload.Event, target.ID, err)
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func (d *Dispatcher) post(ctx context.Context, target models.Webhook, payload Payload, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, payload.Event)
+	req.Header.Set(HeaderDelivery, payload.ID)
+	req.Header.Set(HeaderSignature, Sign(target.Secret, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// Sign returns the signature header value for body.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func newDeliveryID() string {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return fmt.Sprintf("%d", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(buf)
+}
@@ -0,0 +1,4 @@
+-- Track games the provider moved out of their original week (postponements, flexed games).
+alter table games
+	add column if not exists moved_from_week_id uuid references season_weeks(id) on delete set null,
+	add column if not exists moved_at timestamptz;
@@ -0,0 +1,4 @@
+-- How picks on tied games are scored: 'zero' (no credit), 'half' (half a point) or 'win' (everyone correct).
+alter table season_settings
+	add column if not exists tie_scoring text not null default 'zero'
+		check (tie_scoring in ('zero', 'half', 'win'));
@@ -0,0 +1,6 @@
+-- Record who decided each game's result so syncs never clobber a commissioner override.
+alter table games
+	add column if not exists result_source text not null default 'provider'
+		check (result_source in ('provider', 'manual')),
+	add column if not exists result_reason text,
+	add column if not exists result_updated_at timestamptz;
@@ -0,0 +1,4 @@
+-- Default ordering for the season leaderboard: 'weeks-won', 'total-correct' or 'win-pct'.
+alter table season_settings
+	add column if not exists standings_rule text not null default 'weeks-won'
+		check (standings_rule in ('weeks-won', 'total-correct', 'win-pct'));
@@ -0,0 +1,6 @@
+-- Betting lines from the provider. point_spread is from the home team's view (negative means home favored).
+alter table games
+	add column if not exists point_spread double precision,
+	add column if not exists over_under double precision,
+	add column if not exists home_moneyline int,
+	add column if not exists away_moneyline int;
@@ -0,0 +1,11 @@
+-- Derived highlights for each week ('biggest-upset', 'closest-game', 'decided-week'), recomputed whenever results change.
+create table if not exists game_tags (
+	season_week_id uuid not null references season_weeks(id) on delete cascade,
+	game_id uuid not null references games(id) on delete cascade,
+	tag text not null check (tag in ('biggest-upset', 'closest-game', 'decided-week')),
+	value double precision,
+	created_at timestamptz not null default now(),
+	primary key (season_week_id, tag)
+);
+
+create index if not exists game_tags_game_id_idx on game_tags (game_id);
@@ -0,0 +1,12 @@
+-- Per-week rating history. Rows are rebuilt from picks whenever a week's results change.
+create table if not exists member_ratings (
+	season_week_id uuid not null references season_weeks(id) on delete cascade,
+	member_id uuid not null references family_members(id) on delete cascade,
+	rating double precision not null,
+	change double precision not null,
+	games int not null,
+	created_at timestamptz not null default now(),
+	primary key (season_week_id, member_id)
+);
+
+create index if not exists member_ratings_member_id_idx on member_ratings (member_id);
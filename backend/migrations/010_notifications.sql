@@ -0,0 +1,8 @@
+-- One row per notification sent for a week so reminders go out once, even across restarts.
+create table if not exists notification_log (
+	season_week_id uuid not null references season_weeks(id) on delete cascade,
+	kind text not null,
+	recipients int not null default 0,
+	sent_at timestamptz not null default now(),
+	primary key (season_week_id, kind)
+);
@@ -0,0 +1,30 @@
+-- Commissioner-managed outgoing webhooks and a log of every delivery attempt.
+create table if not exists webhooks (
+	id uuid primary key default gen_random_uuid(),
+	url text not null,
+	secret text not null,
+	events text[] not null,
+	active boolean not null default true,
+	created_by_member_id uuid references family_members(id),
+	created_at timestamptz not null default now(),
+	updated_at timestamptz not null default now()
+);
+
+create trigger set_updated_at_webhooks
+before update on webhooks
+for each row execute function set_updated_at();
+
+create table if not exists webhook_deliveries (
+	id uuid primary key default gen_random_uuid(),
+	webhook_id uuid not null references webhooks(id) on delete cascade,
+	delivery_id text not null,
+	event text not null,
+	payload jsonb not null,
+	attempt int not null,
+	status_code int,
+	error text,
+	delivered boolean not null default false,
+	created_at timestamptz not null default now()
+);
+
+create index if not exists webhook_deliveries_webhook_id_idx on webhook_deliveries (webhook_id, created_at desc);
@@ -0,0 +1,8 @@
+-- Per-season rule for filling in missed picks once a game locks at kickoff.
+alter table season_settings
+	add column if not exists auto_pick_rule text not null default 'none'
+		check (auto_pick_rule in ('none', 'home', 'favorite', 'consensus', 'fade-leader'));
+
+-- Picks the server made on a member's behalf; a manual pick clears the flag.
+alter table picks
+	add column if not exists is_auto boolean not null default false;
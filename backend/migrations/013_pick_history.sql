@@ -0,0 +1,18 @@
+-- Who entered each pick when it was not the member themselves (the
+-- commissioner entering picks on someone's behalf).
+alter table picks
+	add column if not exists entered_by uuid references family_members(id) on delete set null;
+
+-- Append-only audit trail of every pick change.
+create table if not exists pick_history (
+	id uuid primary key default gen_random_uuid(),
+	member_id uuid not null references family_members(id) on delete cascade,
+	game_id uuid not null references games(id) on delete cascade,
+	action text not null check (action in ('saved', 'deleted', 'auto')),
+	chosen_side text check (chosen_side in ('home', 'away')),
+	entered_by uuid references family_members(id) on delete set null,
+	created_at timestamptz not null default now()
+);
+
+create index if not exists pick_history_game_id_idx on pick_history (game_id, created_at);
+create index if not exists pick_history_member_id_idx on pick_history (member_id, created_at);
@@ -28,6 +28,13 @@ type GameSnapshot struct {
 	Status    string  `json:"status"`
 	IsClosed  bool    `json:"isClosed"`
 	IsOver    bool    `json:"isOver"`
+
+	// Betting lines, when SportsData.io has them. PointSpread is from the
+	// home team's view: negative means the home team is favored.
+	PointSpread   *float64 `json:"pointSpread,omitempty"`
+	OverUnder     *float64 `json:"overUnder,omitempty"`
+	HomeMoneyLine *int     `json:"homeMoneyLine,omitempty"`
+	AwayMoneyLine *int     `json:"awayMoneyLine,omitempty"`
 }
 
 // FetchScoresByWeek retrieves minimal game data for the given season/week.
@@ -167,6 +174,11 @@ type scoreResponse struct {
 	IsClosed    bool            `json:"IsClosed"`
 	IsOver      bool            `json:"IsOver"`
 	Stadium     *stadiumDetails `json:"StadiumDetails"`
+
+	PointSpread       *float64 `json:"PointSpread"`
+	OverUnder         *float64 `json:"OverUnder"`
+	HomeTeamMoneyLine *int     `json:"HomeTeamMoneyLine"`
+	AwayTeamMoneyLine *int     `json:"AwayTeamMoneyLine"`
 }
 
 type stadiumDetails struct {
@@ -205,6 +217,11 @@ func (s scoreResponse) toSnapshot() GameSnapshot {
 		Status:    s.Status,
 		IsClosed:  s.IsClosed,
 		IsOver:    s.IsOver,
+
+		PointSpread:   s.PointSpread,
+		OverUnder:     s.OverUnder,
+		HomeMoneyLine: s.HomeTeamMoneyLine,
+		AwayMoneyLine: s.AwayTeamMoneyLine,
 	}
 }
 
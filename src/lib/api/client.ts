@@ -1,4 +1,112 @@
 import { PUBLIC_API_BASE_URL } from '$env/static/public';
+import {
+	createWebhookPath,
+	declareWinnerPath,
+	deletePickPath,
+	deleteWebhookPath,
+	exportArchivePath,
+	exportSeasonCSVPath,
+	getCalendarPath,
+	getContendersPath,
+	getCurrentWeekPath,
+	getFeedPath,
+	getHallOfFamePath,
+	getHeadToHeadPath,
+	getMemberStatsPath,
+	getPageDataPath,
+	getProbabilitiesPath,
+	getRatingsPath,
+	getRecapPath,
+	getSeasonHeadToHeadPath,
+	getStandingsPath,
+	importSeasonCSVPath,
+	listPickHistoryPath,
+	listSeasonsPath,
+	listSeasonWeeksPath,
+	listWebhooksPath,
+	parsePicksPath,
+	restoreArchivePath,
+	setGameWinnerPath,
+	syncWeekPath,
+	unlockGamePath,
+	upsertPickPath,
+	upsertTieBreakerPath,
+	type ArchiveRestore,
+	type Consensus,
+	type CreateWebhookResponse,
+	type DeclareWinnerBody,
+	type DeclareWinnerResponse,
+	type DeletePickBody,
+	type DeletePickResponse,
+	type Game,
+	type GetContendersResponse,
+	type GetCurrentWeekResponse,
+	type GetHallOfFameResponse,
+	type GetHeadToHeadResponse,
+	type GetMemberStatsResponse,
+	type GetPageDataResponse,
+	type GetProbabilitiesResponse,
+	type GetRatingsResponse,
+	type GetStandingsResponse,
+	type ImportSeasonCSVResponse,
+	type ListPickHistoryResponse,
+	type ListSeasonsResponse,
+	type ListSeasonWeeksResponse,
+	type ListWebhooksResponse,
+	type ParsePicksBody,
+	type ParsePicksResponse,
+	type PickHistoryEntry,
+	type PickParseResult,
+	type RecordSummary,
+	type RestoreArchiveResponse,
+	type SeasonImport,
+	type SetGameWinnerBody,
+	type SetGameWinnerResponse,
+	type Standings,
+	type SyncWeekBody,
+	type SyncWeekResponse,
+	type UnlockGameResponse,
+	type UpsertPickBody,
+	type UpsertPickResponse,
+	type UpsertTieBreakerBody,
+	type UpsertTieBreakerResponse,
+	type Webhook
+} from './generated';
+
+// Types and paths come from ./generated, which `go run ./cmd/openapi -ts`
+// writes from the API's OpenAPI document. The names below are the ones the
+// pages import.
+export type {
+	ArchiveRestore,
+	HeadToHeadSide,
+	PickHistoryEntry,
+	PickParseIssue,
+	PickParseResult,
+	SeasonImport,
+	StatLine,
+	SyncConflict,
+	SyncGameDiff,
+	SyncGameState,
+	SyncWeekResponse,
+	Webhook,
+	WeekSummary
+} from './generated';
+
+export type SeasonsResponse = ListSeasonsResponse;
+export type WeeksResponse = ListSeasonWeeksResponse;
+export type RecordSummaryResponse = RecordSummary;
+export type PageDataResponse = GetPageDataResponse;
+export type GameTag = NonNullable<Game['tags']>[number];
+export type GameConsensus = Consensus;
+export type StandingsRule = Standings['rule'];
+export type StandingsResponse = GetStandingsResponse;
+export type ContendersResponse = GetContendersResponse;
+export type ProbabilitiesResponse = GetProbabilitiesResponse;
+export type MemberStatsResponse = GetMemberStatsResponse;
+export type HeadToHeadResponse = GetHeadToHeadResponse;
+export type HallOfFameResponse = GetHallOfFameResponse;
+export type RatingsResponse = GetRatingsResponse;
+export type WebhookEvent = Webhook['events'][number];
 
 const API_BASE = (PUBLIC_API_BASE_URL ?? '').replace(/\/$/, '');
 
@@ -36,72 +144,8 @@ async function apiFetch<T>(fetchFn: typeof fetch, path: string, init?: RequestIn
 	return (await response.json()) as T;
 }
 
-export type SeasonsResponse = {
-	seasons: Array<{
-		id: string;
-		label: string;
-		year: number;
-		sportsDataSeasonKey: string;
-	}>;
-};
-
-export type WeeksResponse = {
-	weeks: Array<{
-		id: string;
-		number: number;
-		label: string;
-		startsAt?: string | null;
-		endsAt?: string | null;
-	}>;
-};
-
-export type PageDataResponse = {
-	season: {
-		id: string;
-		label: string;
-		year: number;
-		sportsDataSeasonKey: string;
-	};
-	weeks: WeeksResponse['weeks'];
-	activeWeek: WeeksResponse['weeks'][number];
-	members: Array<{
-		id: string;
-		name: string;
-		isCommissioner: boolean;
-		seasonRecord: { wins: number; losses: number };
-		lastWeekRecord: { wins: number; losses: number };
-		weeksWon: number;
-		tieBreakers: Record<number, number>;
-	}>;
-	games: Array<{
-		id: string;
-		gameKey: string;
-		kickoff?: string | null;
-		location: string;
-		status: string;
-		channel?: string;
-		homeTeam: { code: string; name: string; location: string };
-		awayTeam: { code: string; name: string; location: string };
-		homeScore?: number | null;
-		awayScore?: number | null;
-		winner?: string | null;
-		picks: Array<{
-			memberId: string;
-			chosenSide: string;
-			status: string;
-		}>;
-	}>;
-	weekResult?: {
-		seasonWeekId: string;
-		winnerMemberId?: string | null;
-		declaredByMemberId?: string | null;
-		notes?: string | null;
-		declaredAt?: string | null;
-	} | null;
-};
-
 export async function fetchSeasons(fetchFn: typeof fetch): Promise<SeasonsResponse['seasons']> {
-	const { seasons } = await apiFetch<SeasonsResponse>(fetchFn, '/api/seasons');
+	const { seasons } = await apiFetch<SeasonsResponse>(fetchFn, listSeasonsPath());
 	return seasons;
 }
 
@@ -109,7 +153,7 @@ export async function fetchWeeks(
 	fetchFn: typeof fetch,
 	seasonId: string
 ): Promise<WeeksResponse['weeks']> {
-	const { weeks } = await apiFetch<WeeksResponse>(fetchFn, `/api/seasons/${seasonId}/weeks`);
+	const { weeks } = await apiFetch<WeeksResponse>(fetchFn, listSeasonWeeksPath(seasonId));
 	return weeks;
 }
 
@@ -117,9 +161,9 @@ export async function fetchCurrentWeek(
 	fetchFn: typeof fetch,
 	seasonId: string
 ): Promise<number | null> {
-	const { weekNumber } = await apiFetch<{ weekNumber: number }>(
+	const { weekNumber } = await apiFetch<GetCurrentWeekResponse>(
 		fetchFn,
-		`/api/seasons/${seasonId}/weeks/current`
+		getCurrentWeekPath(seasonId)
 	);
 	return weekNumber ?? null;
 }
@@ -129,7 +173,7 @@ export async function fetchPageData(
 	seasonId: string,
 	weekNumber: number
 ): Promise<PageDataResponse> {
-	return apiFetch<PageDataResponse>(fetchFn, `/api/seasons/${seasonId}/weeks/${weekNumber}`);
+	return apiFetch<PageDataResponse>(fetchFn, getPageDataPath(seasonId, weekNumber));
 }
 
 export async function upsertPick(
@@ -140,39 +184,42 @@ export async function upsertPick(
 		memberId: string;
 		gameKey: string;
 		side: 'home' | 'away';
+		enteredBy?: string;
 	}
 ) {
-	return apiFetch<{ pick: { memberId: string; chosenSide: string; status: string } }>(
-		fetchFn,
-		`/api/seasons/${params.seasonId}/weeks/${params.weekNumber}/picks`,
-		{
-			method: 'POST',
-			body: JSON.stringify({
-				memberId: params.memberId,
-				gameKey: params.gameKey,
-				side: params.side
-			})
-		}
-	);
+	const body: UpsertPickBody = {
+		memberId: params.memberId,
+		gameKey: params.gameKey,
+		side: params.side,
+		enteredBy: params.enteredBy
+	};
+	return apiFetch<UpsertPickResponse>(fetchFn, upsertPickPath(params.seasonId, params.weekNumber), {
+		method: 'POST',
+		body: JSON.stringify(body)
+	});
 }
 
 export async function clearPick(
 	fetchFn: typeof fetch,
-	params: { seasonId: string; weekNumber: number; memberId: string; gameKey: string }
+	params: {
+		seasonId: string;
+		weekNumber: number;
+		memberId: string;
+		gameKey: string;
+		enteredBy?: string;
+	}
 ) {
-	const qs = new URLSearchParams({
-		memberId: params.memberId,
-		gameKey: params.gameKey
-	});
-	return apiFetch<{ removed: boolean }>(
+	const body: DeletePickBody = { memberId: params.memberId, gameKey: params.gameKey };
+	return apiFetch<DeletePickResponse>(
 		fetchFn,
-		`/api/seasons/${params.seasonId}/weeks/${params.weekNumber}/picks?${qs.toString()}`,
+		deletePickPath(params.seasonId, params.weekNumber, {
+			memberId: params.memberId,
+			gameKey: params.gameKey,
+			enteredBy: params.enteredBy || undefined
+		}),
 		{
 			method: 'DELETE',
-			body: JSON.stringify({
-				memberId: params.memberId,
-				gameKey: params.gameKey
-			})
+			body: JSON.stringify(body)
 		}
 	);
 }
@@ -181,15 +228,13 @@ export async function upsertTieBreaker(
 	fetchFn: typeof fetch,
 	params: { seasonId: string; weekNumber: number; memberId: string; points: number }
 ) {
-	return apiFetch<{ tieBreaker: { memberId: string; weekNumber: number; points: number } }>(
+	const body: UpsertTieBreakerBody = { memberId: params.memberId, points: params.points };
+	return apiFetch<UpsertTieBreakerResponse>(
 		fetchFn,
-		`/api/seasons/${params.seasonId}/weeks/${params.weekNumber}/tie-breaker`,
+		upsertTieBreakerPath(params.seasonId, params.weekNumber),
 		{
 			method: 'POST',
-			body: JSON.stringify({
-				memberId: params.memberId,
-				points: params.points
-			})
+			body: JSON.stringify(body)
 		}
 	);
 }
@@ -204,43 +249,269 @@ export async function declareWeekWinner(
 		notes?: string;
 	}
 ) {
-	return apiFetch<{ weekResult: PageDataResponse['weekResult'] }>(
+	const body: DeclareWinnerBody = {
+		winnerMemberId: params.winnerMemberId ?? '',
+		declaredByMemberId: params.declaredByMemberId,
+		notes: params.notes ?? ''
+	};
+	return apiFetch<DeclareWinnerResponse>(
 		fetchFn,
-		`/api/seasons/${params.seasonId}/weeks/${params.weekNumber}/winner`,
+		declareWinnerPath(params.seasonId, params.weekNumber),
 		{
 			method: 'POST',
-			body: JSON.stringify({
-				winnerMemberId: params.winnerMemberId,
-				declaredByMemberId: params.declaredByMemberId,
-				notes: params.notes ?? ''
-			})
+			body: JSON.stringify(body)
 		}
 	);
 }
 
 export async function syncWeek(
 	fetchFn: typeof fetch,
-	params: { seasonId: string; weekNumber: number }
+	params: { seasonId: string; weekNumber: number; overrideManual?: boolean; dryRun?: boolean }
 ) {
-	return apiFetch<{ syncedGames: number }>(
+	const body: SyncWeekBody = {
+		overrideManual: params.overrideManual ?? false,
+		dryRun: params.dryRun ?? false
+	};
+	return apiFetch<SyncWeekResponse>(fetchFn, syncWeekPath(params.seasonId, params.weekNumber), {
+		method: 'POST',
+		body: JSON.stringify(body)
+	});
+}
+
+export async function setGameWinner(
+	fetchFn: typeof fetch,
+	params: {
+		seasonId: string;
+		weekNumber: number;
+		gameKey: string;
+		winner: 'home' | 'away' | 'tie' | null;
+		reason?: string;
+	}
+) {
+	const body: SetGameWinnerBody = { winner: params.winner ?? null, reason: params.reason ?? '' };
+	return apiFetch<SetGameWinnerResponse>(
 		fetchFn,
-		`/api/seasons/${params.seasonId}/weeks/${params.weekNumber}/sync`,
-		{ method: 'POST' }
+		setGameWinnerPath(params.seasonId, params.weekNumber, params.gameKey),
+		{
+			method: 'POST',
+			body: JSON.stringify(body)
+		}
 	);
 }
 
-export async function setGameWinner(
+export async function unlockGame(
 	fetchFn: typeof fetch,
-	params: { seasonId: string; weekNumber: number; gameKey: string; winner: 'home' | 'away' | null }
+	params: { seasonId: string; weekNumber: number; gameKey: string }
 ) {
-	const encodedKey = encodeURIComponent(params.gameKey);
-	return apiFetch<{ game: PageDataResponse['games'][number] }>(
+	return apiFetch<UnlockGameResponse>(
+		fetchFn,
+		unlockGamePath(params.seasonId, params.weekNumber, params.gameKey),
+		{ method: 'POST' }
+	);
+}
+
+export async function fetchStandings(
+	fetchFn: typeof fetch,
+	params: { seasonId: string; rule?: StandingsRule; week?: number }
+): Promise<StandingsResponse['standings']> {
+	const { standings } = await apiFetch<StandingsResponse>(
+		fetchFn,
+		getStandingsPath(params.seasonId, { rule: params.rule, week: params.week || undefined })
+	);
+	return standings;
+}
+
+export async function fetchContenders(
+	fetchFn: typeof fetch,
+	params: { seasonId: string; weekNumber: number; allowTies?: boolean }
+): Promise<ContendersResponse['contenders']> {
+	const { contenders } = await apiFetch<ContendersResponse>(
+		fetchFn,
+		getContendersPath(params.seasonId, params.weekNumber, {
+			allowTies: params.allowTies ?? true
+		})
+	);
+	return contenders;
+}
+
+export async function fetchProbabilities(
+	fetchFn: typeof fetch,
+	params: { seasonId: string; weekNumber: number; iterations?: number; seed?: number }
+): Promise<ProbabilitiesResponse['probabilities']> {
+	const { probabilities } = await apiFetch<ProbabilitiesResponse>(
+		fetchFn,
+		getProbabilitiesPath(params.seasonId, params.weekNumber, {
+			iterations: params.iterations || undefined,
+			seed: params.seed
+		})
+	);
+	return probabilities;
+}
+
+export async function fetchMemberStats(
+	fetchFn: typeof fetch,
+	params: { seasonId: string; memberId: string }
+): Promise<MemberStatsResponse['stats']> {
+	const { stats } = await apiFetch<MemberStatsResponse>(
+		fetchFn,
+		getMemberStatsPath(params.seasonId, params.memberId)
+	);
+	return stats;
+}
+
+export async function fetchHeadToHead(
+	fetchFn: typeof fetch,
+	params: { memberId: string; opponentId: string; seasonId?: string }
+): Promise<HeadToHeadResponse['headToHead']> {
+	const path = params.seasonId
+		? getSeasonHeadToHeadPath(params.seasonId, params.memberId, params.opponentId)
+		: getHeadToHeadPath(params.memberId, params.opponentId);
+	const { headToHead } = await apiFetch<HeadToHeadResponse>(fetchFn, path);
+	return headToHead;
+}
+
+export async function fetchHallOfFame(
+	fetchFn: typeof fetch
+): Promise<HallOfFameResponse['hallOfFame']> {
+	const { hallOfFame } = await apiFetch<HallOfFameResponse>(fetchFn, getHallOfFamePath());
+	return hallOfFame;
+}
+
+export async function fetchRecapText(
+	fetchFn: typeof fetch,
+	params: { seasonId: string; weekNumber: number; format?: 'markdown' | 'text' }
+): Promise<string> {
+	const response = await fetchFn(
+		resolvePath(
+			getRecapPath(params.seasonId, params.weekNumber, { format: params.format ?? 'markdown' })
+		),
+		{ credentials: 'include' }
+	);
+	if (!response.ok) {
+		throw new Error(`${response.status} ${response.statusText}`);
+	}
+	return response.text();
+}
+
+export async function fetchRatings(
+	fetchFn: typeof fetch,
+	seasonId: string
+): Promise<RatingsResponse['ratings']> {
+	const { ratings } = await apiFetch<RatingsResponse>(fetchFn, getRatingsPath(seasonId));
+	return ratings;
+}
+
+export async function fetchWebhooks(fetchFn: typeof fetch, memberId: string): Promise<Webhook[]> {
+	const { webhooks } = await apiFetch<ListWebhooksResponse>(fetchFn, listWebhooksPath({ memberId }));
+	return webhooks;
+}
+
+export async function createWebhook(
+	fetchFn: typeof fetch,
+	params: { memberId: string; url: string; events: WebhookEvent[]; secret?: string }
+): Promise<Webhook> {
+	const { webhook } = await apiFetch<CreateWebhookResponse>(fetchFn, createWebhookPath(), {
+		method: 'POST',
+		body: JSON.stringify(params)
+	});
+	return webhook;
+}
+
+export async function deleteWebhook(
+	fetchFn: typeof fetch,
+	params: { memberId: string; webhookId: string }
+): Promise<void> {
+	await apiFetch(fetchFn, deleteWebhookPath(params.webhookId, { memberId: params.memberId }), {
+		method: 'DELETE'
+	});
+}
+
+export async function fetchPickHistory(
+	fetchFn: typeof fetch,
+	params: { seasonId: string; weekNumber: number; memberId?: string }
+): Promise<PickHistoryEntry[]> {
+	const { history } = await apiFetch<ListPickHistoryResponse>(
 		fetchFn,
-		`/api/seasons/${params.seasonId}/weeks/${params.weekNumber}/games/${encodedKey}/winner`,
+		listPickHistoryPath(params.seasonId, params.weekNumber, {
+			memberId: params.memberId || undefined
+		})
+	);
+	return history;
+}
+
+export async function parsePicks(
+	fetchFn: typeof fetch,
+	params: {
+		seasonId: string;
+		weekNumber: number;
+		memberId: string;
+		message: string;
+		enteredBy?: string;
+		commit?: boolean;
+	}
+): Promise<PickParseResult> {
+	const body: ParsePicksBody = {
+		memberId: params.memberId,
+		message: params.message,
+		enteredBy: params.enteredBy,
+		commit: params.commit ?? false
+	};
+	const { result } = await apiFetch<ParsePicksResponse>(
+		fetchFn,
+		parsePicksPath(params.seasonId, params.weekNumber),
+		{
+			method: 'POST',
+			body: JSON.stringify(body)
+		}
+	);
+	return result;
+}
+
+export function seasonCsvUrl(seasonId: string): string {
+	return resolvePath(exportSeasonCSVPath(seasonId));
+}
+
+export async function importSeasonCsv(
+	fetchFn: typeof fetch,
+	params: { memberId: string; seasonKey: string; csv: string; label?: string; dryRun?: boolean }
+): Promise<SeasonImport> {
+	const { import: report } = await apiFetch<ImportSeasonCSVResponse>(
+		fetchFn,
+		importSeasonCSVPath({
+			memberId: params.memberId,
+			seasonKey: params.seasonKey,
+			label: params.label || undefined,
+			dryRun: params.dryRun || undefined
+		}),
 		{
 			method: 'POST',
-			body: JSON.stringify({ winner: params.winner ?? null })
+			headers: { 'Content-Type': 'text/csv' },
+			body: params.csv
 		}
 	);
+	return report;
+}
+
+export function archiveUrl(memberId: string, seasonId?: string): string {
+	return resolvePath(exportArchivePath({ memberId, seasonId: seasonId || undefined }));
 }
 
+export async function restoreArchive(
+	fetchFn: typeof fetch,
+	params: { memberId: string; archive: string }
+): Promise<ArchiveRestore> {
+	const { restore } = await apiFetch<RestoreArchiveResponse>(
+		fetchFn,
+		restoreArchivePath({ memberId: params.memberId }),
+		{ method: 'POST', body: params.archive }
+	);
+	return restore;
+}
+
+export function calendarUrl(seasonId: string, memberId?: string): string {
+	return resolvePath(getCalendarPath(seasonId, { memberId: memberId || undefined }));
+}
+
+export function feedUrl(seasonId: string): string {
+	return resolvePath(getFeedPath(seasonId));
+}
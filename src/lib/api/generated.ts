@@ -0,0 +1,984 @@
+// Code generated by cmd/openapi from the API's OpenAPI document; DO NOT EDIT.
+
+export type QueryValue = string | number | boolean | undefined;
+
+function withQuery(path: string, query?: Record<string, QueryValue>): string {
+	if (!query) return path;
+	const qs = new URLSearchParams();
+	for (const [key, value] of Object.entries(query)) {
+		if (value !== undefined) qs.set(key, String(value));
+	}
+	const encoded = qs.toString();
+	return encoded ? `${path}?${encoded}` : path;
+}
+
+export type Archive = {
+	exportedAt: string;
+	members: ArchiveMember[];
+	seasons: ArchiveSeason[];
+	version: number;
+};
+
+export type ArchiveGame = {
+	awayMoneyline?: number | null;
+	awayScore?: number | null;
+	awayTeam: string;
+	channel?: string;
+	gameKey: string;
+	homeMoneyline?: number | null;
+	homeScore?: number | null;
+	homeTeam: string;
+	id: string;
+	kickoff?: string | null;
+	location?: string;
+	movedFromWeek?: number | null;
+	overUnder?: number | null;
+	picks: ArchivePick[];
+	pointSpread?: number | null;
+	resultReason?: string;
+	resultSource: string;
+	status: string;
+	winner?: string;
+};
+
+export type ArchiveMember = {
+	id: string;
+	isCommissioner: boolean;
+	name: string;
+};
+
+export type ArchivePick = {
+	auto?: boolean;
+	enteredBy?: string;
+	memberId: string;
+	side: string;
+};
+
+export type ArchiveRestore = {
+	games: number;
+	members: number;
+	membersCreated: number;
+	picks: number;
+	seasons: number;
+	seasonsCreated: number;
+	tieBreakers: number;
+	titles: number;
+	weekResults: number;
+	weeks: number;
+};
+
+export type ArchiveResult = {
+	declaredAt: string;
+	declaredById?: string;
+	notes?: string;
+	winnerId?: string;
+};
+
+export type ArchiveSeason = {
+	id: string;
+	label: string;
+	seasonKey: string;
+	settings?: ArchiveSettings | null;
+	title?: ArchiveResult | null;
+	weeks: ArchiveWeek[];
+	year: number;
+};
+
+export type ArchiveSettings = {
+	autoPickRule: string;
+	currentWeek: number;
+	standingsRule: string;
+	tieScoring: string;
+};
+
+export type ArchiveTieBreaker = {
+	memberId: string;
+	points: number;
+};
+
+export type ArchiveWeek = {
+	endsAt?: string | null;
+	games: ArchiveGame[];
+	id: string;
+	label: string;
+	number: number;
+	result?: ArchiveResult | null;
+	startsAt?: string | null;
+	tieBreakers: ArchiveTieBreaker[];
+};
+
+export type Consensus = {
+	awayPct: number;
+	awayPicks: number;
+	homePct: number;
+	homePicks: number;
+	loneWolves: string[];
+	side?: 'home' | 'away';
+	unanimous: boolean;
+};
+
+export type ConsensusStats = {
+	agreed: number;
+	games: number;
+	rate: number;
+};
+
+export type ContenderReport = {
+	allowTies: boolean;
+	remainingGames: number;
+	remainingWeeks: number;
+	season: SeasonContender[];
+	seasonId: string;
+	seasonRule: 'weeks-won' | 'total-correct' | 'win-pct';
+	week: WeekContender[];
+	weekNumber: number;
+};
+
+export type ApiError = {
+	error: string;
+};
+
+export type Game = {
+	awayMoneyLine?: number | null;
+	awayScore?: number | null;
+	awayTeam: TeamInfo;
+	channel?: string;
+	consensus?: Consensus | null;
+	gameKey: string;
+	homeMoneyLine?: number | null;
+	homeScore?: number | null;
+	homeTeam: TeamInfo;
+	id: string;
+	kickoff?: string | null;
+	location: string;
+	movedFromWeek?: number | null;
+	overUnder?: number | null;
+	picks: GamePick[];
+	pointSpread?: number | null;
+	resultReason?: string;
+	resultSource?: 'provider' | 'manual';
+	resultUpdatedAt?: string | null;
+	status: string;
+	tags?: Array<'biggest-upset' | 'closest-game' | 'decided-week'>;
+	winner?: string;
+};
+
+export type GamePick = {
+	auto?: boolean;
+	chosenSide: string;
+	enteredBy?: string;
+	memberId: string;
+	status: string;
+};
+
+export type HallOfFame = {
+	members: HallOfFameEntry[];
+	records: HallOfFameRecord[];
+	seasons: number;
+};
+
+export type HallOfFameEntry = {
+	bestWeek?: HallOfFameWeek | null;
+	memberId: string;
+	name: string;
+	record: RecordSummary;
+	seasons: number;
+	streaks: StreakStats;
+	titleSeasons: string[];
+	titles: number;
+	weeksWon: number;
+	winPct: number;
+};
+
+export type HallOfFameRecord = {
+	category: 'most-titles' | 'most-weeks-won' | 'most-correct' | 'best-week' | 'longest-streak';
+	holders: RecordHolder[];
+	value: number;
+};
+
+export type HallOfFameWeek = {
+	record: RecordSummary;
+	seasonId: string;
+	seasonLabel: string;
+	weekNumber: number;
+};
+
+export type HeadToHead = {
+	disagreements: HeadToHeadGame[];
+	member: HeadToHeadSide;
+	opponent: HeadToHeadSide;
+	seasonId?: string;
+	weeks: HeadToHeadWeek[];
+	weeksLevel: number;
+};
+
+export type HeadToHeadGame = {
+	awayTeam: string;
+	gameKey: string;
+	homeTeam: string;
+	memberSide: 'home' | 'away';
+	opponentSide: 'home' | 'away';
+	seasonId: string;
+	weekNumber: number;
+	winner?: string;
+	winnerId?: string;
+};
+
+export type HeadToHeadSide = {
+	disagreementsWon: number;
+	memberId: string;
+	name: string;
+	record: RecordSummary;
+	weeksAhead: number;
+	weeksWon: number;
+};
+
+export type HeadToHeadWeek = {
+	leaderId?: string;
+	memberPoints: number;
+	opponentPoints: number;
+	seasonId: string;
+	weekNumber: number;
+};
+
+export type Member = {
+	id: string;
+	isCommissioner: boolean;
+	lastWeekRecord: RecordSummary;
+	name: string;
+	rating?: number | null;
+	seasonRecord: RecordSummary;
+	seasonWinProbability?: number | null;
+	tieBreakers: Record<string, number>;
+	weekWinProbability?: number | null;
+	weeksWon: number;
+};
+
+export type MemberProbability = {
+	memberId: string;
+	season: number;
+	week: number;
+};
+
+export type MemberRating = {
+	history: RatingPoint[];
+	memberId: string;
+	name: string;
+	rating: number;
+	startRating: number;
+};
+
+export type MemberStats = {
+	away: StatLine;
+	bestWeek?: WeeklyRecord | null;
+	consensus: ConsensusStats;
+	contrarian: StatLine;
+	favorite: StatLine;
+	home: StatLine;
+	memberId: string;
+	name: string;
+	overall: StatLine;
+	seasonId: string;
+	streaks: StreakStats;
+	teams: TeamStats[];
+	underdog: StatLine;
+	worstWeek?: WeeklyRecord | null;
+};
+
+export type PageData = {
+	activeWeek: Week;
+	games: Game[];
+	members: Member[];
+	season: Season;
+	summary: WeekSummary;
+	weekResult?: WeekResult | null;
+	weeks: Week[];
+};
+
+export type ParsedPick = {
+	gameKey: string;
+	side: 'home' | 'away';
+	team: TeamInfo;
+	text: string;
+};
+
+export type PickHistoryEntry = {
+	action: 'saved' | 'deleted' | 'auto';
+	chosenSide?: 'home' | 'away';
+	createdAt: string;
+	enteredBy?: string;
+	enteredByName?: string;
+	gameKey: string;
+	id: string;
+	memberId: string;
+	memberName: string;
+};
+
+export type PickParseIssue = {
+	candidates?: TeamInfo[];
+	kind: 'unknown' | 'not-playing' | 'ambiguous' | 'conflict';
+	text: string;
+};
+
+export type PickParseResult = {
+	committed: boolean;
+	issues: PickParseIssue[];
+	memberId: string;
+	message: string;
+	missing: string[];
+	picks: ParsedPick[];
+	tieBreaker?: number | null;
+};
+
+export type ProbabilityReport = {
+	iterations: number;
+	members: MemberProbability[];
+	seasonId: string;
+	seasonRule: 'weeks-won' | 'total-correct' | 'win-pct';
+	weekNumber: number;
+};
+
+export type RatingPoint = {
+	change: number;
+	games: number;
+	rating: number;
+	weekNumber: number;
+};
+
+export type RatingReport = {
+	members: MemberRating[];
+	seasonId: string;
+};
+
+export type RecapHighlight = {
+	awayScore?: number | null;
+	awayTeam: string;
+	gameKey: string;
+	homeScore?: number | null;
+	homeTeam: string;
+	tag: string;
+};
+
+export type RecapMember = {
+	memberId: string;
+	name: string;
+	record: RecordSummary;
+	tieBreaker?: number | null;
+};
+
+export type RecapPick = {
+	correct: boolean;
+	gameKey: string;
+	memberId: string;
+	name: string;
+	opponent: string;
+	picks: number;
+	shared: number;
+	team: string;
+};
+
+export type RecapTieBreaker = {
+	actualTotal?: number | null;
+	awayTeam: string;
+	decided: boolean;
+	gameKey: string;
+	homeTeam: string;
+	margin?: number | null;
+	winnerGuess?: number | null;
+};
+
+export type RecapUpset = {
+	awayScore?: number | null;
+	byLine: boolean;
+	fooled: number;
+	gameKey: string;
+	homeScore?: number | null;
+	loser: string;
+	picks: number;
+	winner: string;
+};
+
+export type RecordHolder = {
+	memberId: string;
+	name: string;
+	seasonId?: string;
+	seasonLabel?: string;
+	weekNumber?: number;
+};
+
+export type RecordSummary = {
+	losses: number;
+	points: number;
+	ties: number;
+	wins: number;
+};
+
+export type Season = {
+	id: string;
+	label: string;
+	sportsDataSeasonKey: string;
+	year: number;
+};
+
+export type SeasonContender = {
+	bestCase: number;
+	canWinTitle: boolean;
+	current: number;
+	memberId: string;
+};
+
+export type SeasonImport = {
+	champion?: string;
+	dryRun: boolean;
+	games: number;
+	label: string;
+	members: number;
+	membersCreated: number;
+	picks: number;
+	seasonId: string;
+	tieBreakers: number;
+	weekWinners: number;
+	weeks: number;
+};
+
+export type SeasonSettings = {
+	autoPickRule: string;
+	currentWeek: number;
+	seasonId: string;
+	standingsRule: string;
+	tieScoring: string;
+};
+
+export type SeasoncsvProblem = {
+	line: number;
+	message: string;
+};
+
+export type Standings = {
+	entries: StandingsEntry[];
+	rule: 'weeks-won' | 'total-correct' | 'win-pct';
+	seasonId: string;
+	throughWeek: number;
+	weeks: number[];
+};
+
+export type StandingsEntry = {
+	gamesBehind: number;
+	memberId: string;
+	movement: number;
+	name: string;
+	previousRank?: number | null;
+	rank: number;
+	record: RecordSummary;
+	tied: boolean;
+	weeks: WeeklyRecord[];
+	weeksWon: number;
+	winPct: number;
+};
+
+export type StatLine = {
+	accuracy: number;
+	picks: number;
+	record: RecordSummary;
+};
+
+export type StreakStats = {
+	current: number;
+	longestCorrect: number;
+	longestIncorrect: number;
+};
+
+export type SyncConflict = {
+	awayScore?: number | null;
+	gameKey: string;
+	homeScore?: number | null;
+	manualWinner: string;
+	overridden: boolean;
+	providerStatus: string;
+	providerWinner: string;
+};
+
+export type SyncGameDiff = {
+	after: SyncGameState;
+	awayTeam: string;
+	before?: SyncGameState | null;
+	changes: Array<'new' | 'week' | 'kickoff' | 'score' | 'status' | 'winner' | 'picks-regraded'>;
+	gameKey: string;
+	homeTeam: string;
+	regradedPicks: number;
+};
+
+export type SyncGameState = {
+	awayScore?: number | null;
+	homeScore?: number | null;
+	kickoff?: string | null;
+	status: string;
+	weekNumber: number;
+	winner?: string;
+};
+
+export type TeamInfo = {
+	code: string;
+	location: string;
+	name: string;
+};
+
+export type TeamStats = {
+	against: StatLine;
+	backed: StatLine;
+	games: StatLine;
+	team: string;
+};
+
+export type Webhook = {
+	active: boolean;
+	createdAt: string;
+	createdByMemberId?: string;
+	events: Array<'pick.saved' | 'pick.deleted' | 'game.result' | 'week.synced' | 'week.winner'>;
+	id: string;
+	secret?: string;
+	url: string;
+};
+
+export type WebhookDelivery = {
+	attempt: number;
+	createdAt: string;
+	delivered: boolean;
+	deliveryId: string;
+	error?: string;
+	event: string;
+	id: string;
+	statusCode?: number | null;
+	webhookId: string;
+};
+
+export type Week = {
+	endsAt?: string | null;
+	id: string;
+	label: string;
+	number: number;
+	startsAt?: string | null;
+};
+
+export type WeekContender = {
+	canWin: boolean;
+	currentPoints: number;
+	maxPoints: number;
+	memberId: string;
+};
+
+export type WeekRecap = {
+	bestPicks: RecapPick[];
+	declared: boolean;
+	highlights: RecapHighlight[];
+	leaderboard: RecapMember[];
+	notes?: string;
+	seasonId: string;
+	seasonLabel: string;
+	standings: StandingsEntry[];
+	tieBreaker?: RecapTieBreaker | null;
+	upsets: RecapUpset[];
+	weekLabel: string;
+	weekNumber: number;
+	winner?: RecapMember | null;
+	worstPicks: RecapPick[];
+};
+
+export type WeekResult = {
+	declaredAt?: string | null;
+	declaredByMemberId?: string;
+	notes?: string;
+	seasonWeekId: string;
+	winnerMemberId?: string;
+};
+
+export type WeekSummary = {
+	consensusRecord: RecordSummary;
+	games: number;
+	loneWolfCorrect: number;
+	loneWolfPicks: number;
+	picks: number;
+	splitGames: number;
+	unanimousGames: number;
+};
+
+export type WeeklyRecord = {
+	record: RecordSummary;
+	weekNumber: number;
+	wonWeek: boolean;
+};
+
+// POST /api/webhooks
+export type CreateWebhookResponse = {
+	webhook: Webhook;
+};
+export type CreateWebhookBody = {
+	events: Array<'pick.saved' | 'pick.deleted' | 'game.result' | 'week.synced' | 'week.winner'>;
+	memberId: string;
+	secret?: string;
+	url: string;
+};
+export function createWebhookPath(): string {
+	return `/api/webhooks`;
+}
+
+// POST /api/seasons/{seasonID}/weeks/{weekNumber}/winner
+export type DeclareWinnerResponse = {
+	weekResult: WeekResult;
+};
+export type DeclareWinnerBody = {
+	declaredByMemberId: string;
+	notes?: string;
+	winnerMemberId: string;
+};
+export function declareWinnerPath(seasonID: string, weekNumber: number): string {
+	return `/api/seasons/${encodeURIComponent(seasonID)}/weeks/${encodeURIComponent(weekNumber)}/winner`;
+}
+
+// DELETE /api/seasons/{seasonID}/weeks/{weekNumber}/picks
+export type DeletePickResponse = {
+	removed: boolean;
+};
+export type DeletePickBody = {
+	enteredBy?: string;
+	gameKey?: string;
+	memberId?: string;
+};
+export type DeletePickQuery = {
+	enteredBy?: string;
+	gameKey?: string;
+	memberId?: string;
+};
+export function deletePickPath(seasonID: string, weekNumber: number, query?: DeletePickQuery): string {
+	return withQuery(`/api/seasons/${encodeURIComponent(seasonID)}/weeks/${encodeURIComponent(weekNumber)}/picks`, query);
+}
+
+// DELETE /api/webhooks/{webhookID}
+export type DeleteWebhookResponse = {
+	removed: boolean;
+};
+export type DeleteWebhookQuery = {
+	memberId: string;
+};
+export function deleteWebhookPath(webhookID: string, query: DeleteWebhookQuery): string {
+	return withQuery(`/api/webhooks/${encodeURIComponent(webhookID)}`, query);
+}
+
+// GET /api/archive
+export type ExportArchiveResponse = Archive;
+export type ExportArchiveQuery = {
+	memberId: string;
+	seasonId?: string;
+};
+export function exportArchivePath(query: ExportArchiveQuery): string {
+	return withQuery(`/api/archive`, query);
+}
+
+// GET /api/seasons/{seasonID}/export.csv
+export function exportSeasonCSVPath(seasonID: string): string {
+	return `/api/seasons/${encodeURIComponent(seasonID)}/export.csv`;
+}
+
+// GET /api/seasons/{seasonID}/calendar.ics
+export type GetCalendarQuery = {
+	memberId?: string;
+};
+export function getCalendarPath(seasonID: string, query?: GetCalendarQuery): string {
+	return withQuery(`/api/seasons/${encodeURIComponent(seasonID)}/calendar.ics`, query);
+}
+
+// GET /api/seasons/{seasonID}/weeks/{weekNumber}/contenders
+export type GetContendersResponse = {
+	contenders: ContenderReport;
+};
+export type GetContendersQuery = {
+	allowTies?: boolean;
+};
+export function getContendersPath(seasonID: string, weekNumber: number, query?: GetContendersQuery): string {
+	return withQuery(`/api/seasons/${encodeURIComponent(seasonID)}/weeks/${encodeURIComponent(weekNumber)}/contenders`, query);
+}
+
+// GET /api/seasons/{seasonID}/weeks/current
+export type GetCurrentWeekResponse = {
+	weekNumber: number;
+};
+export function getCurrentWeekPath(seasonID: string): string {
+	return `/api/seasons/${encodeURIComponent(seasonID)}/weeks/current`;
+}
+
+// GET /api/seasons/{seasonID}/feed.atom
+export function getFeedPath(seasonID: string): string {
+	return `/api/seasons/${encodeURIComponent(seasonID)}/feed.atom`;
+}
+
+// GET /api/hall-of-fame
+export type GetHallOfFameResponse = {
+	hallOfFame: HallOfFame;
+};
+export function getHallOfFamePath(): string {
+	return `/api/hall-of-fame`;
+}
+
+// GET /api/members/{memberID}/head-to-head/{opponentID}
+export type GetHeadToHeadResponse = {
+	headToHead: HeadToHead;
+};
+export function getHeadToHeadPath(memberID: string, opponentID: string): string {
+	return `/api/members/${encodeURIComponent(memberID)}/head-to-head/${encodeURIComponent(opponentID)}`;
+}
+
+// GET /healthz
+export type GetHealthResponse = {
+	status: string;
+};
+export function getHealthPath(): string {
+	return `/healthz`;
+}
+
+// GET /api/seasons/{seasonID}/members/{memberID}/stats
+export type GetMemberStatsResponse = {
+	stats: MemberStats;
+};
+export function getMemberStatsPath(seasonID: string, memberID: string): string {
+	return `/api/seasons/${encodeURIComponent(seasonID)}/members/${encodeURIComponent(memberID)}/stats`;
+}
+
+// GET /api/openapi.json
+export type GetOpenAPIResponse = Record<string, unknown>;
+export function getOpenAPIPath(): string {
+	return `/api/openapi.json`;
+}
+
+// GET /api/seasons/{seasonID}/weeks/{weekNumber}
+export type GetPageDataResponse = PageData;
+export function getPageDataPath(seasonID: string, weekNumber: number): string {
+	return `/api/seasons/${encodeURIComponent(seasonID)}/weeks/${encodeURIComponent(weekNumber)}`;
+}
+
+// GET /api/seasons/{seasonID}/weeks/{weekNumber}/probabilities
+export type GetProbabilitiesResponse = {
+	probabilities: ProbabilityReport;
+};
+export type GetProbabilitiesQuery = {
+	iterations?: number;
+	seed?: number;
+};
+export function getProbabilitiesPath(seasonID: string, weekNumber: number, query?: GetProbabilitiesQuery): string {
+	return withQuery(`/api/seasons/${encodeURIComponent(seasonID)}/weeks/${encodeURIComponent(weekNumber)}/probabilities`, query);
+}
+
+// GET /api/seasons/{seasonID}/ratings
+export type GetRatingsResponse = {
+	ratings: RatingReport;
+};
+export function getRatingsPath(seasonID: string): string {
+	return `/api/seasons/${encodeURIComponent(seasonID)}/ratings`;
+}
+
+// GET /api/seasons/{seasonID}/weeks/{weekNumber}/recap
+export type GetRecapResponse = {
+	recap: WeekRecap;
+};
+export type GetRecapQuery = {
+	format?: 'markdown' | 'text' | 'json';
+};
+export function getRecapPath(seasonID: string, weekNumber: number, query?: GetRecapQuery): string {
+	return withQuery(`/api/seasons/${encodeURIComponent(seasonID)}/weeks/${encodeURIComponent(weekNumber)}/recap`, query);
+}
+
+// GET /api/seasons/{seasonID}/members/{memberID}/head-to-head/{opponentID}
+export type GetSeasonHeadToHeadResponse = {
+	headToHead: HeadToHead;
+};
+export function getSeasonHeadToHeadPath(seasonID: string, memberID: string, opponentID: string): string {
+	return `/api/seasons/${encodeURIComponent(seasonID)}/members/${encodeURIComponent(memberID)}/head-to-head/${encodeURIComponent(opponentID)}`;
+}
+
+// GET /api/seasons/{seasonID}/settings
+export type GetSeasonSettingsResponse = {
+	settings: SeasonSettings;
+};
+export function getSeasonSettingsPath(seasonID: string): string {
+	return `/api/seasons/${encodeURIComponent(seasonID)}/settings`;
+}
+
+// GET /api/seasons/{seasonID}/standings
+export type GetStandingsResponse = {
+	standings: Standings;
+};
+export type GetStandingsQuery = {
+	rule?: string;
+	week?: number;
+};
+export function getStandingsPath(seasonID: string, query?: GetStandingsQuery): string {
+	return withQuery(`/api/seasons/${encodeURIComponent(seasonID)}/standings`, query);
+}
+
+// POST /api/seasons/import
+export type ImportSeasonCSVResponse = {
+	import: SeasonImport;
+};
+export type ImportSeasonCSVQuery = {
+	dryRun?: boolean;
+	label?: string;
+	memberId: string;
+	seasonKey: string;
+};
+export function importSeasonCSVPath(query: ImportSeasonCSVQuery): string {
+	return withQuery(`/api/seasons/import`, query);
+}
+
+// GET /api/seasons/{seasonID}/weeks/{weekNumber}/picks/history
+export type ListPickHistoryResponse = {
+	history: PickHistoryEntry[];
+};
+export type ListPickHistoryQuery = {
+	memberId?: string;
+};
+export function listPickHistoryPath(seasonID: string, weekNumber: number, query?: ListPickHistoryQuery): string {
+	return withQuery(`/api/seasons/${encodeURIComponent(seasonID)}/weeks/${encodeURIComponent(weekNumber)}/picks/history`, query);
+}
+
+// GET /api/seasons/{seasonID}/weeks
+export type ListSeasonWeeksResponse = {
+	weeks: Week[];
+};
+export function listSeasonWeeksPath(seasonID: string): string {
+	return `/api/seasons/${encodeURIComponent(seasonID)}/weeks`;
+}
+
+// GET /api/seasons
+export type ListSeasonsResponse = {
+	seasons: Season[];
+};
+export function listSeasonsPath(): string {
+	return `/api/seasons`;
+}
+
+// GET /api/webhooks/{webhookID}/deliveries
+export type ListWebhookDeliveriesResponse = {
+	deliveries: WebhookDelivery[];
+};
+export type ListWebhookDeliveriesQuery = {
+	limit?: number;
+	memberId: string;
+};
+export function listWebhookDeliveriesPath(webhookID: string, query: ListWebhookDeliveriesQuery): string {
+	return withQuery(`/api/webhooks/${encodeURIComponent(webhookID)}/deliveries`, query);
+}
+
+// GET /api/webhooks
+export type ListWebhooksResponse = {
+	webhooks: Webhook[];
+};
+export type ListWebhooksQuery = {
+	memberId: string;
+};
+export function listWebhooksPath(query: ListWebhooksQuery): string {
+	return withQuery(`/api/webhooks`, query);
+}
+
+// POST /api/seasons/{seasonID}/weeks/{weekNumber}/picks/parse
+export type ParsePicksResponse = {
+	result: PickParseResult;
+};
+export type ParsePicksBody = {
+	commit?: boolean;
+	enteredBy?: string;
+	memberId: string;
+	message: string;
+};
+export function parsePicksPath(seasonID: string, weekNumber: number): string {
+	return `/api/seasons/${encodeURIComponent(seasonID)}/weeks/${encodeURIComponent(weekNumber)}/picks/parse`;
+}
+
+// POST /api/archive/restore
+export type RestoreArchiveResponse = {
+	restore: ArchiveRestore;
+};
+export type RestoreArchiveBody = Archive;
+export type RestoreArchiveQuery = {
+	memberId: string;
+};
+export function restoreArchivePath(query: RestoreArchiveQuery): string {
+	return withQuery(`/api/archive/restore`, query);
+}
+
+// POST /api/seasons/{seasonID}/weeks/{weekNumber}/games/{gameKey}/winner
+export type SetGameWinnerResponse = {
+	game: Game;
+};
+export type SetGameWinnerBody = {
+	reason?: string;
+	winner: string | null;
+};
+export function setGameWinnerPath(seasonID: string, weekNumber: number, gameKey: string): string {
+	return `/api/seasons/${encodeURIComponent(seasonID)}/weeks/${encodeURIComponent(weekNumber)}/games/${encodeURIComponent(gameKey)}/winner`;
+}
+
+// POST /api/seasons/{seasonID}/weeks/{weekNumber}/sync
+export type SyncWeekResponse = {
+	conflicts: SyncConflict[];
+	dryRun: boolean;
+	games: SyncGameDiff[];
+	syncedGames: number;
+	unchanged: number;
+};
+export type SyncWeekBody = {
+	dryRun?: boolean;
+	overrideManual?: boolean;
+};
+export function syncWeekPath(seasonID: string, weekNumber: number): string {
+	return `/api/seasons/${encodeURIComponent(seasonID)}/weeks/${encodeURIComponent(weekNumber)}/sync`;
+}
+
+// POST /api/seasons/{seasonID}/weeks/{weekNumber}/games/{gameKey}/unlock
+export type UnlockGameResponse = {
+	game: Game;
+};
+export function unlockGamePath(seasonID: string, weekNumber: number, gameKey: string): string {
+	return `/api/seasons/${encodeURIComponent(seasonID)}/weeks/${encodeURIComponent(weekNumber)}/games/${encodeURIComponent(gameKey)}/unlock`;
+}
+
+// POST /api/seasons/{seasonID}/settings
+export type UpdateSeasonSettingsResponse = {
+	settings: SeasonSettings;
+};
+export type UpdateSeasonSettingsBody = {
+	autoPickRule?: 'none' | 'home' | 'favorite' | 'consensus' | 'fade-leader';
+	standingsRule?: 'weeks-won' | 'total-correct' | 'win-pct';
+	tieScoring?: 'zero' | 'half' | 'win';
+};
+export function updateSeasonSettingsPath(seasonID: string): string {
+	return `/api/seasons/${encodeURIComponent(seasonID)}/settings`;
+}
+
+// POST /api/seasons/{seasonID}/weeks/{weekNumber}/picks
+export type UpsertPickResponse = {
+	pick: GamePick;
+};
+export type UpsertPickBody = {
+	enteredBy?: string;
+	gameKey: string;
+	memberId: string;
+	side: string;
+};
+export function upsertPickPath(seasonID: string, weekNumber: number): string {
+	return `/api/seasons/${encodeURIComponent(seasonID)}/weeks/${encodeURIComponent(weekNumber)}/picks`;
+}
+
+// POST /api/seasons/{seasonID}/weeks/{weekNumber}/tie-breaker
+export type UpsertTieBreakerResponse = {
+	tieBreaker: {
+		memberId: string;
+		points: number;
+		weekNumber: number;
+	};
+};
+export type UpsertTieBreakerBody = {
+	memberId: string;
+	points: number;
+};
+export function upsertTieBreakerPath(seasonID: string, weekNumber: number): string {
+	return `/api/seasons/${encodeURIComponent(seasonID)}/weeks/${encodeURIComponent(weekNumber)}/tie-breaker`;
+}
@@ -12,6 +12,8 @@ export type Week = {
 export type RecordSummary = {
 	wins: number;
 	losses: number;
+	ties: number;
+	points: number;
 };
 
 export type Member = {
@@ -24,6 +26,9 @@ export type Member = {
 	tieBreakers: {
 		[weekNumber: number]: number;
 	};
+	weekWinProbability?: number;
+	seasonWinProbability?: number;
+	rating?: number;
 };
 
 export type TeamInfo = {
@@ -32,12 +37,14 @@ export type TeamInfo = {
 	location: string;
 };
 
-export type PickStatus = 'pending' | 'correct' | 'incorrect';
+export type PickStatus = 'pending' | 'correct' | 'incorrect' | 'void' | 'push';
 
 export type GamePick = {
 	memberId: string;
 	chosenSide: 'home' | 'away';
 	status: PickStatus;
+	auto?: boolean;
+	enteredBy?: string;
 };
 
 export type Game = {
@@ -45,14 +52,32 @@ export type Game = {
 	gameKey: string;
 	kickoff?: string | null;
 	location: string;
-	status: 'scheduled' | 'in-progress' | 'final';
+	status:
+		| 'scheduled'
+		| 'in-progress'
+		| 'final'
+		| 'postponed'
+		| 'suspended'
+		| 'canceled'
+		| 'forfeit';
 	channel?: string | null;
 	homeTeam: TeamInfo;
 	awayTeam: TeamInfo;
 	homeScore?: number | null;
 	awayScore?: number | null;
-	winner?: 'home' | 'away' | null;
+	winner?: 'home' | 'away' | 'tie' | null;
+	movedFromWeek?: number | null;
 	picks: GamePick[];
+	consensus?: {
+		homePicks: number;
+		awayPicks: number;
+		homePct: number;
+		awayPct: number;
+		side?: 'home' | 'away';
+		unanimous: boolean;
+		loneWolves: string[];
+	};
+	tags?: Array<'biggest-upset' | 'closest-game' | 'decided-week'>;
 };
 
 export type PicksPageData = {
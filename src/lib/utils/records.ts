@@ -1,3 +1,6 @@
-export function formatRecord(record: { wins: number; losses: number }): string {
+export function formatRecord(record: { wins: number; losses: number; ties?: number }): string {
+	if (record.ties) {
+		return `${record.wins}-${record.losses}-${record.ties}`;
+	}
 	return `${record.wins}-${record.losses}`;
 }
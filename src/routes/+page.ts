@@ -1,5 +1,11 @@
 import type { PageLoad } from './$types';
-import { fetchCurrentWeek, fetchPageData, fetchSeasons, fetchWeeks } from '$lib/api/client';
+import {
+	fetchContenders,
+	fetchCurrentWeek,
+	fetchPageData,
+	fetchSeasons,
+	fetchWeeks
+} from '$lib/api/client';
 
 export const load = (async ({ fetch, url }) => {
 	const seasons = await fetchSeasons(fetch);
@@ -12,6 +18,7 @@ export const load = (async ({ fetch, url }) => {
 			members: [],
 			games: [],
 			weekResult: null,
+			contenders: null,
 			selectedSeasonId: null,
 			selectedWeekNumber: null
 		};
@@ -46,6 +53,16 @@ export const load = (async ({ fetch, url }) => {
 
 	const pageData = await fetchPageData(fetch, selectedSeasonId, selectedWeekNumber);
 
+	let contenders = null;
+	try {
+		contenders = await fetchContenders(fetch, {
+			seasonId: selectedSeasonId,
+			weekNumber: pageData.activeWeek.number
+		});
+	} catch (error) {
+		console.error('Unable to load contenders, computing them locally.', error);
+	}
+
 	return {
 		seasons,
 		selectedSeasonId,
@@ -55,6 +72,7 @@ export const load = (async ({ fetch, url }) => {
 		activeWeek: pageData.activeWeek,
 		members: pageData.members,
 		games: pageData.games,
-		weekResult: pageData.weekResult ?? null
+		weekResult: pageData.weekResult ?? null,
+		contenders
 	};
 }) satisfies PageLoad;